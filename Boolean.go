@@ -0,0 +1,320 @@
+package latlong
+
+import (
+	"sort"
+
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+)
+
+// Areal is operand of boolean operations, which is Polygon, MultiPolygon or *Rect.
+// Circle is available by Circle.Polygon.
+type Areal interface {
+	S2Polygon() *s2.Polygon
+}
+
+// S2Polygon is getter for s2.Polygon of all rings. Polygons must not overlap each other.
+func (cds MultiPolygon) S2Polygon() *s2.Polygon {
+	var loops []*s2.Loop
+	for i := range cds {
+		if len(cds[i].MultiPoint) == 0 {
+			continue
+		}
+		loops = append(loops, cds[i].S2Loop())
+		for _, h := range cds[i].Holes {
+			loops = append(loops, ringLoop(h.MultiPoint))
+		}
+	}
+	return s2.PolygonFromLoops(loops)
+}
+
+// Polygon returns Polygon of circumference loop with div vertices, which precision is booleanPrec.
+func (c *Circle) Polygon(div int) Polygon {
+	vs := c.S2Loop(div).Vertices()
+	if len(vs) == 0 {
+		return NewPolygon(LineString{})
+	}
+	return NewPolygon(boolRing(vs))
+}
+
+// booleanOperand is edges of s2.Polygon with the interior on the left, split at the other operand.
+type booleanOperand struct {
+	polygon *s2.Polygon
+	edges   []s2.Edge
+	splits  [][]s2.Point
+}
+
+func newBooleanOperand(a Areal) *booleanOperand {
+	o := &booleanOperand{polygon: a.S2Polygon()}
+	for i := 0; i < o.polygon.NumEdges(); i++ {
+		o.edges = append(o.edges, o.polygon.Edge(i))
+	}
+	o.splits = make([][]s2.Point, len(o.edges))
+	return o
+}
+
+// snap replaces vertices of b near vertices of a by them, so that shared vertices are identical.
+func (b *booleanOperand) snap(a *booleanOperand) {
+	for i := range b.edges {
+		for _, v := range []*s2.Point{&b.edges[i].V0, &b.edges[i].V1} {
+			for _, e := range a.edges {
+				if v.Distance(e.V0) <= pointTolerance {
+					*v = e.V0
+					break
+				}
+			}
+		}
+	}
+}
+
+// split finds crossings of edges and vertices on edges of the other.
+func (a *booleanOperand) split(b *booleanOperand) {
+	near := func(p s2.Point, e s2.Edge) bool {
+		return p.Distance(e.V0) <= pointTolerance || p.Distance(e.V1) <= pointTolerance
+	}
+	type bound struct {
+		c s2.Point
+		r s1.Angle
+	}
+	bounds := func(es []s2.Edge) []bound {
+		bs := make([]bound, len(es))
+		for i, e := range es {
+			bs[i] = bound{s2.Point{Vector: e.V0.Add(e.V1.Vector).Normalize()}, e.V0.Distance(e.V1)/2 + pointTolerance}
+		}
+		return bs
+	}
+	ba, bb := bounds(a.edges), bounds(b.edges)
+	for i, ea := range a.edges {
+		for j, eb := range b.edges {
+			if ba[i].c.Distance(bb[j].c) > ba[i].r+bb[j].r {
+				continue
+			}
+			if !near(eb.V0, ea) && s2.DistanceFromSegment(eb.V0, ea.V0, ea.V1) <= pointTolerance {
+				a.splits[i] = append(a.splits[i], eb.V0)
+			}
+			if !near(ea.V0, eb) && s2.DistanceFromSegment(ea.V0, eb.V0, eb.V1) <= pointTolerance {
+				b.splits[j] = append(b.splits[j], ea.V0)
+			}
+			if s2.CrossingSign(ea.V0, ea.V1, eb.V0, eb.V1) == s2.Cross {
+				// crossings at vertices are found above.
+				if x := s2.Intersection(ea.V0, ea.V1, eb.V0, eb.V1); !near(x, ea) && !near(x, eb) {
+					a.splits[i] = append(a.splits[i], x)
+					b.splits[j] = append(b.splits[j], x)
+				}
+			}
+		}
+	}
+}
+
+// subEdges returns edges split at splits in order.
+func (a *booleanOperand) subEdges() (es []s2.Edge) {
+	for i, e := range a.edges {
+		ps := a.splits[i]
+		sort.Slice(ps, func(j, k int) bool {
+			return e.V0.Distance(ps[j]) < e.V0.Distance(ps[k])
+		})
+		v := e.V0
+		for _, p := range ps {
+			if v.Distance(p) > pointTolerance && e.V1.Distance(p) > pointTolerance {
+				es = append(es, s2.Edge{V0: v, V1: p})
+				v = p
+			}
+		}
+		es = append(es, s2.Edge{V0: v, V1: e.V1})
+	}
+	return
+}
+
+// edgeClass is position of sub edge relative to the other operand.
+type edgeClass int
+
+const (
+	edgeOutside edgeClass = iota
+	edgeInside
+	edgeShared   // shared edge in same direction.
+	edgeOpposite // shared edge in opposite direction.
+)
+
+// classify returns class of each edge of es relative to the other operand of edges os.
+func classify(es, os []s2.Edge, other *s2.Polygon) []edgeClass {
+	type key struct{ v0, v1 s2.Point }
+	m := make(map[key]bool, len(os))
+	for _, e := range os {
+		m[key{e.V0, e.V1}] = true
+	}
+	cs := make([]edgeClass, len(es))
+	for i, e := range es {
+		switch {
+		case m[key{e.V0, e.V1}]:
+			cs[i] = edgeShared
+		case m[key{e.V1, e.V0}]:
+			cs[i] = edgeOpposite
+		case other.ContainsPoint(s2.Point{Vector: e.V0.Add(e.V1.Vector).Normalize()}):
+			cs[i] = edgeInside
+		}
+	}
+	return cs
+}
+
+// booleanOperation returns edges of a selected by keepA, and edges of b selected by keepB,
+// which are reversed if the class is in reverse.
+func booleanOperation(a, b Areal, keepA, keepB map[edgeClass]bool, reverse map[edgeClass]bool) MultiPolygon {
+	oa, ob := newBooleanOperand(a), newBooleanOperand(b)
+	ob.snap(oa)
+	oa.split(ob)
+	ea, eb := oa.subEdges(), ob.subEdges()
+	ca, cb := classify(ea, eb, ob.polygon), classify(eb, ea, oa.polygon)
+
+	var es []s2.Edge
+	for _, x := range []struct {
+		es   []s2.Edge
+		cs   []edgeClass
+		keep map[edgeClass]bool
+	}{{ea, ca, keepA}, {eb, cb, keepB}} {
+		for i, e := range x.es {
+			if x.keep[x.cs[i]] {
+				if reverse[x.cs[i]] {
+					e = s2.Edge{V0: e.V1, V1: e.V0}
+				}
+				es = append(es, e)
+			}
+		}
+	}
+	return assemblePolygons(es)
+}
+
+// assemblePolygons connects edges with the interior on the left to loops,
+// and returns Polygons of CCW shells and CW holes.
+func assemblePolygons(es []s2.Edge) MultiPolygon {
+	out := make(map[s2.Point][]int)
+	for i, e := range es {
+		out[e.V0] = append(out[e.V0], i)
+	}
+	used := make([]bool, len(es))
+
+	var shells, holes [][]s2.Point
+	for start := range es {
+		if used[start] {
+			continue
+		}
+		used[start] = true
+		ring := []s2.Point{es[start].V0}
+		cur := start
+		for es[cur].V1 != es[start].V0 {
+			// the sharpest left turn separates loops touching at a vertex.
+			v, back := es[cur].V1, es[cur].V0
+			next := -1
+			for _, i := range out[v] {
+				if !used[i] && (next < 0 || s2.OrderedCCW(es[next].V1, es[i].V1, back, v)) {
+					next = i
+				}
+			}
+			if next < 0 {
+				ring = nil // broken by numerical error.
+				break
+			}
+			used[next] = true
+			ring = append(ring, v)
+			cur = next
+		}
+		for _, ring := range splitRing(ring) {
+			if len(ring) < 3 {
+				continue
+			}
+			if s2.LoopFromPoints(ring).IsNormalized() {
+				shells = append(shells, ring)
+			} else {
+				holes = append(holes, ring)
+			}
+		}
+	}
+
+	loops := make([]*s2.Loop, len(shells))
+	mp := make(MultiPolygon, len(shells))
+	for i := range shells {
+		loops[i] = s2.LoopFromPoints(shells[i])
+		mp[i] = NewPolygon(boolRing(shells[i]))
+	}
+	for _, h := range holes {
+		// the hole belongs to the smallest shell containing a point just left of its edge.
+		m := h[0].Add(h[1].Vector).Normalize().Add(h[0].PointCross(h[1]).Normalize().Mul(1e-9))
+		p := s2.Point{Vector: m.Normalize()}
+		best := -1
+		for i, l := range loops {
+			if l.ContainsPoint(p) && (best < 0 || l.Area() < loops[best].Area()) {
+				best = i
+			}
+		}
+		if best >= 0 {
+			mp[best].Holes = append(mp[best].Holes, boolRing(h))
+		}
+	}
+	return mp
+}
+
+// splitRing splits ring at repeated vertices into simple rings,
+// so that a hole touching its shell is separated from the shell.
+func splitRing(ring []s2.Point) (rings [][]s2.Point) {
+	var stack []s2.Point
+	pos := make(map[s2.Point]int)
+	for _, v := range ring {
+		if k, ok := pos[v]; ok {
+			rings = append(rings, append([]s2.Point(nil), stack[k:]...))
+			for _, u := range stack[k+1:] {
+				delete(pos, u)
+			}
+			stack = stack[:k+1]
+			continue
+		}
+		pos[v] = len(stack)
+		stack = append(stack, v)
+	}
+	return append(rings, stack)
+}
+
+// booleanPrec is precision of generated vertices, about 1cm.
+const booleanPrec = 1e-7 * s1.Degree
+
+// booleanPoint returns Point of p with booleanPrec.
+func booleanPoint(p s2.Point) Point {
+	ll := s2.LatLngFromPoint(p)
+	return NewPoint(NewAngleFromS1Angle(ll.Lat, booleanPrec), NewAngleFromS1Angle(ll.Lng, booleanPrec), nil)
+}
+
+// boolRing returns closed LineString of ps.
+func boolRing(ps []s2.Point) LineString {
+	cds := make(MultiPoint, len(ps)+1)
+	for i := range ps {
+		cds[i] = booleanPoint(ps[i])
+	}
+	cds[len(ps)] = cds[0]
+	return LineString{MultiPoint: cds}
+}
+
+// Union returns area in a or b.
+func Union(a, b Areal) MultiPolygon {
+	return booleanOperation(a, b,
+		map[edgeClass]bool{edgeOutside: true, edgeShared: true},
+		map[edgeClass]bool{edgeOutside: true}, nil)
+}
+
+// Intersection returns area in both of a and b.
+func Intersection(a, b Areal) MultiPolygon {
+	return booleanOperation(a, b,
+		map[edgeClass]bool{edgeInside: true, edgeShared: true},
+		map[edgeClass]bool{edgeInside: true}, nil)
+}
+
+// Difference returns area in a but not in b.
+func Difference(a, b Areal) MultiPolygon {
+	return booleanOperation(a, b,
+		map[edgeClass]bool{edgeOutside: true, edgeOpposite: true},
+		map[edgeClass]bool{edgeInside: true},
+		map[edgeClass]bool{edgeInside: true})
+}
+
+// SymmetricDifference returns area in either a or b but not in both.
+func SymmetricDifference(a, b Areal) MultiPolygon {
+	keep := map[edgeClass]bool{edgeOutside: true, edgeInside: true}
+	return booleanOperation(a, b, keep, keep, map[edgeClass]bool{edgeInside: true})
+}
@@ -0,0 +1,107 @@
+package latlong_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	latlong "github.com/toyo/go-latlong"
+)
+
+func TestBoolean(t *testing.T) {
+	pt := func(lat, lng float64) latlong.Point {
+		return latlong.NewPoint(latlong.NewAngle(lat, 0), latlong.NewAngle(lng, 0), nil)
+	}
+	square := func(lat, lng, size float64) latlong.Polygon {
+		return latlong.NewPolygon(latlong.LineString{MultiPoint: latlong.MultiPoint{
+			pt(lat, lng), pt(lat, lng+size), pt(lat+size, lng+size), pt(lat+size, lng), pt(lat, lng)}})
+	}
+	near := func(a, b float64) bool {
+		return math.Abs(a-b) <= 1e-9*math.Abs(b)
+	}
+
+	a, b := square(0, 0, 2), square(1, 1, 2)
+	aa, ab := a.AreaAngle(), b.AreaAngle()
+	i := latlong.Intersection(a, b)
+	if len(i) != 1 || len(i[0].Holes) != 0 || len(i[0].MultiPoint) != 5 {
+		t.Fatalf("was %v", i)
+	}
+	ai := i.AreaAngle()
+	if u := latlong.Union(a, b); len(u) != 1 || len(u[0].MultiPoint) != 9 || !near(u.AreaAngle(), aa+ab-ai) {
+		t.Errorf("was %v", u)
+	}
+	if d := latlong.Difference(a, b); len(d) != 1 || len(d[0].MultiPoint) != 7 || !near(d.AreaAngle(), aa-ai) ||
+		!d.S2Region().ContainsPoint(pt(0.5, 0.5).S2Point()) || d.S2Region().ContainsPoint(pt(1.5, 1.5).S2Point()) {
+		t.Errorf("was %v", d)
+	}
+	if s := latlong.SymmetricDifference(a, b); len(s) != 2 || !near(s.AreaAngle(), aa+ab-2*ai) {
+		t.Errorf("was %v", s)
+	}
+
+	// evacuation zone outside of the exclusion circle.
+	circle := latlong.NewCircle(pt(1, 1), 30).Polygon(32)
+	if d := latlong.Difference(a, circle); len(d) != 1 || len(d[0].Holes) != 1 || len(d[0].Holes[0].MultiPoint) != 33 ||
+		!near(d.AreaAngle(), aa-circle.AreaAngle()) || d.S2Region().ContainsPoint(pt(1, 1).S2Point()) {
+		t.Errorf("was %v", d)
+	}
+	if i := latlong.Intersection(a, circle); len(i) != 1 || !near(i.AreaAngle(), circle.AreaAngle()) {
+		t.Errorf("was %v", i)
+	}
+	// the circle crosses the edge of a.
+	edge := latlong.NewCircle(pt(0, 1), 30).Polygon(32)
+	if i := latlong.Intersection(a, edge); len(i) != 1 || len(i[0].Holes) != 0 || !near(i.AreaAngle(), edge.AreaAngle()/2) {
+		t.Errorf("was %v %v", i.AreaAngle(), edge.AreaAngle()/2)
+	}
+
+	// disjoint, identical and adjacent.
+	c := square(5, 5, 1)
+	if u := latlong.Union(a, c); len(u) != 2 || !near(u.AreaAngle(), aa+c.AreaAngle()) {
+		t.Errorf("was %v", u)
+	}
+	if i := latlong.Intersection(a, c); len(i) != 0 {
+		t.Errorf("was %v", i)
+	}
+	if u := latlong.Union(a, a); len(u) != 1 || !near(u.AreaAngle(), aa) {
+		t.Errorf("was %v", u)
+	}
+	if d := latlong.Difference(a, a); len(d) != 0 {
+		t.Errorf("was %v", d)
+	}
+	r1, r2 := latlong.NewRect(0.5, 0.5, 1, 1), latlong.NewRect(0.5, 1.5, 1, 1)
+	if u := latlong.Union(r1, r2); len(u) != 1 || len(u[0].Holes) != 0 || !near(u.AreaAngle(), r1.Polygon().AreaAngle()+r2.Polygon().AreaAngle()) {
+		t.Errorf("was %v", u)
+	}
+
+	// hole touching the shell at a vertex.
+	tri := latlong.NewPolygon(latlong.LineString{MultiPoint: latlong.MultiPoint{pt(0, 1), pt(1, 1.5), pt(1, 0.5), pt(0, 1)}})
+	if d := latlong.Difference(a, tri); len(d) != 1 || len(d[0].MultiPoint) != 6 || len(d[0].Holes) != 1 || len(d[0].Holes[0].MultiPoint) != 4 ||
+		!near(d.AreaAngle(), aa-tri.AreaAngle()) || d.S2Region().ContainsPoint(pt(0.5, 1).S2Point()) || !d.S2Region().ContainsPoint(pt(1.5, 1).S2Point()) {
+		t.Errorf("was %v", d)
+	}
+
+	// holes and MultiPolygon.
+	ring := latlong.Difference(square(0, 0, 4), square(1, 1, 2))
+	if u := latlong.Union(ring, square(1.5, 1.5, 1)); len(u) != 2 || len(u[0].Holes) != 1 {
+		t.Errorf("was %v", u)
+	}
+	if d := latlong.Difference(ring, square(-1, 2, 6)); len(d) != 1 || len(d[0].Holes) != 0 ||
+		!d.S2Region().ContainsPoint(pt(0.5, 0.5).S2Point()) || d.S2Region().ContainsPoint(pt(1.5, 1.5).S2Point()) {
+		t.Errorf("was %v", d)
+	}
+}
+
+func TestBooleanPrecision(t *testing.T) {
+	p := latlong.NewPoint(latlong.NewAngle(35.68123, 0.00001), latlong.NewAngle(139.76712, 0.00001), nil)
+	u := latlong.Union(latlong.NewCircle(p, 1).Polygon(32), latlong.MultiPolygon{})
+	b, err := json.Marshal(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var u1 latlong.MultiPolygon
+	if err := json.Unmarshal(b, &u1); err != nil {
+		t.Fatal(err)
+	}
+	if len(u1) != 1 || math.Abs(u1.AreaAngle()/u.AreaAngle()-1) > 1e-4 {
+		t.Errorf("was %s", b)
+	}
+}
@@ -0,0 +1,167 @@
+package latlong
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+)
+
+// bufferFrame returns north and east unit vectors at p.
+func bufferFrame(p s2.Point) (north, east r3.Vector) {
+	east = r3.Vector{X: 0, Y: 0, Z: 1}.Cross(p.Vector)
+	if east.Norm() < 1e-15 { // pole.
+		east = r3.Vector{X: 0, Y: 1, Z: 0}
+	}
+	east = east.Normalize()
+	return p.Cross(east), east
+}
+
+// bufferPoint returns point at distance d from p toward bearing.
+func bufferPoint(p s2.Point, bearing float64, d s1.Angle) s2.Point {
+	north, east := bufferFrame(p)
+	dir := north.Mul(math.Cos(bearing)).Add(east.Mul(math.Sin(bearing)))
+	return s2.Point{Vector: p.Mul(math.Cos(float64(d))).Add(dir.Mul(math.Sin(float64(d)))).Normalize()}
+}
+
+// bufferArc appends vertices of arc around c from start to start-π in bearing,
+// which are on the grid of step so that arcs around same c share vertices.
+func bufferArc(ps []s2.Point, c s2.Point, start float64, d s1.Angle, step float64) []s2.Point {
+	const eps = 1e-9
+	for k := math.Floor(start / step); k*step > start-math.Pi+eps; k-- {
+		if k*step < start-eps {
+			ps = append(ps, bufferPoint(c, k*step, d))
+		}
+	}
+	return ps
+}
+
+// bufferSegment returns CCW polygon of points within d from segment a-b with round caps.
+func bufferSegment(a, b s2.Point, d s1.Angle, step float64) Polygon {
+	n := a.PointCross(b).Normalize() // left pole.
+	pieces := int(math.Ceil(float64(a.Distance(b)) / step))
+	side := func(x s2.Point, sign float64) s2.Point {
+		return s2.Point{Vector: x.Mul(math.Cos(float64(d))).Add(n.Mul(sign * math.Sin(float64(d)))).Normalize()}
+	}
+	bearing := func(x s2.Point, v r3.Vector) float64 {
+		north, east := bufferFrame(x)
+		return math.Atan2(v.Dot(east), v.Dot(north))
+	}
+
+	xs := make([]s2.Point, pieces+1)
+	for i := range xs {
+		xs[i] = s2.Interpolate(float64(i)/float64(pieces), a, b)
+	}
+	var ps []s2.Point
+	for i := range xs {
+		ps = append(ps, side(xs[i], -1))
+	}
+	ps = bufferArc(ps, b, bearing(b, n.Mul(-1)), d, step)
+	for i := len(xs) - 1; i >= 0; i-- {
+		ps = append(ps, side(xs[i], 1))
+	}
+	ps = bufferArc(ps, a, bearing(a, n), d, step)
+
+	return NewPolygon(boolRing(ps))
+}
+
+// unionAll returns union of as by merging pairs.
+func unionAll(as []Areal) MultiPolygon {
+	switch len(as) {
+	case 0:
+		return nil
+	case 1:
+		switch a := as[0].(type) {
+		case MultiPolygon:
+			return a
+		case Polygon:
+			return MultiPolygon{a}
+		}
+		return Union(as[0], MultiPolygon{})
+	}
+	return Union(unionAll(as[:len(as)/2]), unionAll(as[len(as)/2:]))
+}
+
+// Buffer returns Polygon of area within km from latlong, which is circle with div vertices.
+// div is at least 4. It is empty if km <= 0.
+func (latlong Point) Buffer(km Km, div int) Polygon {
+	if km <= 0 {
+		return Polygon{}
+	}
+	if div < 4 {
+		div = 4
+	}
+	return NewCircle(latlong, km).Polygon(div)
+}
+
+// Buffer returns MultiPolygon of area within km from any of cds. Circles have div vertices.
+func (cds MultiPoint) Buffer(km Km, div int) MultiPolygon {
+	if km <= 0 {
+		return nil
+	}
+	as := make([]Areal, len(cds))
+	for i := range cds {
+		as[i] = cds[i].Buffer(km, div)
+	}
+	return unionAll(as)
+}
+
+// Buffer returns Polygon of area within km from cds on the sphere, with round caps and joins.
+// div is number of vertices of full circle of caps, which is at least 4,
+// and long segments are also divided by the angle.
+// It may have holes if cds crosses itself. It is empty if km <= 0.
+func (cds LineString) Buffer(km Km, div int) Polygon {
+	if km <= 0 || len(cds.MultiPoint) == 0 {
+		return Polygon{}
+	}
+	if len(cds.MultiPoint) == 1 {
+		return cds.MultiPoint[0].Buffer(km, div)
+	}
+	if mp := cds.buffer(km, div); len(mp) > 0 {
+		return mp[0]
+	}
+	return Polygon{}
+}
+
+func (cds LineString) buffer(km Km, div int) MultiPolygon {
+	if div < 4 {
+		div = 4
+	}
+	d, step := km.EarthAngle(), 2*math.Pi/float64(div)
+	var as []Areal
+	for i := 0; i+1 < len(cds.MultiPoint); i++ {
+		a, b := cds.MultiPoint[i].S2Point(), cds.MultiPoint[i+1].S2Point()
+		if a.Distance(b) > pointTolerance {
+			as = append(as, bufferSegment(a, b, d, step))
+		}
+	}
+	if len(as) == 0 {
+		return MultiPolygon{cds.MultiPoint[0].Buffer(km, div)}
+	}
+	return unionAll(as)
+}
+
+// Buffer returns MultiPolygon of area within km from cds if km > 0,
+// or area of cds farther than -km from the boundary if km < 0, which may be split or empty.
+// div is same as LineString.Buffer.
+func (cds Polygon) Buffer(km Km, div int) MultiPolygon {
+	if len(cds.MultiPoint) == 0 {
+		return nil
+	}
+	if km == 0 {
+		return MultiPolygon{cds}
+	}
+	var as []Areal
+	for _, ring := range append([]LineString{cds.LineString}, cds.Holes...) {
+		cds := ring.MultiPoint
+		if l := len(cds); l > 1 && cds[0].S2LatLng() != cds[l-1].S2LatLng() {
+			cds = append(cds[:l:l], cds[0])
+		}
+		as = append(as, LineString{MultiPoint: cds}.buffer(Km(math.Abs(float64(km))), div))
+	}
+	if km > 0 {
+		return Union(cds, unionAll(as))
+	}
+	return Difference(cds, unionAll(as))
+}
@@ -0,0 +1,99 @@
+package latlong_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	latlong "github.com/toyo/go-latlong"
+)
+
+func TestBuffer(t *testing.T) {
+	pt := func(lat, lng float64) latlong.Point {
+		return latlong.NewPoint(latlong.NewAngle(lat, 0), latlong.NewAngle(lng, 0), nil)
+	}
+	line := func(cds ...latlong.Point) latlong.LineString {
+		return latlong.LineString{MultiPoint: cds}
+	}
+	contains := func(g latlong.Geometry, p latlong.Point) bool {
+		return g.S2Region().ContainsPoint(p.S2Point())
+	}
+	km2 := func(area float64) float64 {
+		return area * 6371.01 * 6371.01
+	}
+
+	if p := pt(35, 135).Buffer(10, 32); len(p.MultiPoint) != 33 || math.Abs(km2(p.AreaAngle())-math.Pi*100) > 4 {
+		t.Errorf("was %v %v", p, km2(p.AreaAngle()))
+	}
+	if p := pt(35, 135).Buffer(0, 32); len(p.MultiPoint) != 0 {
+		t.Errorf("was %v", p)
+	}
+
+	// railway along the equator, which is 111 km.
+	rail := line(pt(0, 0), pt(0, 1))
+	b := rail.Buffer(5, 32)
+	if len(b.Holes) != 0 || !contains(b, pt(0.04, 0.5)) || contains(b, pt(0.05, 0.5)) ||
+		!contains(b, pt(0, 1.04)) || contains(b, pt(0, 1.05)) || !contains(b, pt(0, -0.04)) {
+		t.Errorf("was %v", b)
+	}
+	if a, expct := km2(b.AreaAngle()), 10*111.19+math.Pi*25; math.Abs(a-expct) > 0.01*expct {
+		t.Errorf("expected %v, was %v", expct, a)
+	}
+	b = line(pt(0, 0), pt(0, 1), pt(1, 1)).Buffer(5, 32)
+	if len(b.Holes) != 0 || !contains(b, pt(0.04, 0.5)) || !contains(b, pt(0.5, 1.04)) || !contains(b, pt(1.04, 1)) ||
+		!contains(b, pt(-0.03, 1.03)) || contains(b, pt(-0.04, 1.04)) || contains(b, pt(0.5, 0.5)) {
+		t.Errorf("was %v", b)
+	}
+	b = line(pt(0, 0), pt(0, 1), pt(1, 1), pt(1, 0), pt(0, 0)).Buffer(5, 32)
+	if len(b.Holes) != 1 || contains(b, pt(0.5, 0.5)) || !contains(b, pt(0.96, 0.5)) {
+		t.Errorf("was %v", b)
+	}
+
+	if mp := (latlong.MultiPoint{pt(0, 0), pt(0, 0.1), pt(1, 1)}).Buffer(10, 16); len(mp) != 2 ||
+		!contains(mp, pt(0, 0.05)) || !contains(mp, pt(1, 1)) {
+		t.Errorf("was %v", mp)
+	}
+
+	square := latlong.NewPolygon(line(pt(0, 0), pt(0, 1), pt(1, 1), pt(1, 0), pt(0, 0)))
+	if mp := square.Buffer(5, 32); len(mp) != 1 || len(mp[0].Holes) != 0 || !contains(mp, pt(-0.04, 0.5)) || contains(mp, pt(-0.05, 0.5)) {
+		t.Errorf("was %v", mp)
+	}
+	if mp := square.Buffer(-5, 32); len(mp) != 1 || !contains(mp, pt(0.5, 0.5)) || contains(mp, pt(0.04, 0.5)) || !contains(mp, pt(0.05, 0.5)) {
+		t.Errorf("was %v", mp)
+	}
+	if mp := square.Buffer(-60, 32); len(mp) != 0 {
+		t.Errorf("was %v", mp)
+	}
+	// two squares joined by narrow corridor are split.
+	dumbbell := latlong.NewPolygon(line(pt(0, 0), pt(0, 1), pt(0.45, 1), pt(0.45, 2), pt(0, 2), pt(0, 3),
+		pt(1, 3), pt(1, 2), pt(0.55, 2), pt(0.55, 1), pt(1, 1), pt(1, 0), pt(0, 0)))
+	if mp := dumbbell.Buffer(-10, 32); len(mp) != 2 || !contains(mp, pt(0.5, 0.5)) || !contains(mp, pt(0.5, 2.5)) || contains(mp, pt(0.5, 1.5)) {
+		t.Errorf("was %v", mp)
+	}
+	if mp := latlong.NewPolygon(square.LineString, line(pt(0.4, 0.4), pt(0.4, 0.6), pt(0.6, 0.6), pt(0.6, 0.4))).Buffer(5, 32); len(mp) != 1 ||
+		len(mp[0].Holes) != 1 || contains(mp, pt(0.5, 0.5)) || !contains(mp, pt(0.42, 0.5)) {
+		t.Errorf("was %v", mp)
+	}
+}
+
+func TestBufferMarshal(t *testing.T) {
+	zp := func(lat, lng float64) latlong.Point {
+		return latlong.NewPoint(latlong.NewAngle(lat, 0.00001), latlong.NewAngle(lng, 0.00001), nil)
+	}
+	for _, pg := range []latlong.Polygon{
+		zp(35.68123, 139.76712).Buffer(1, 8),
+		latlong.LineString{MultiPoint: latlong.MultiPoint{zp(35.68123, 139.76712), zp(35.69, 139.77)}}.Buffer(1, 8),
+	} {
+		b, err := json.Marshal(pg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var pg1 latlong.Polygon
+		if err := json.Unmarshal(b, &pg1); err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(pg1.AreaAngle()/pg.AreaAngle()-1) > 1e-4 {
+			t.Errorf("was %s", b)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package latlong
+
+import (
+	"errors"
+	"math"
+
+	"github.com/golang/geo/s1"
+)
+
+// Datum is geodetic datum of Point.
+type Datum int
+
+// Datums. DatumUnknown is zero value of Point.
+const (
+	DatumUnknown Datum = iota
+	DatumTokyo         // Tokyo Datum (日本測地系) on Bessel 1841.
+	DatumJGD2000       // Japanese Geodetic Datum 2000 on GRS80.
+	DatumJGD2011       // Japanese Geodetic Datum 2011 on GRS80.
+	DatumWGS84         // World Geodetic System 1984.
+)
+
+// Bessel1841 is the ellipsoid of Tokyo Datum.
+var Bessel1841 = NewEllipsoid(6377397.155, 1/299.152813)
+
+func (d Datum) String() string {
+	switch d {
+	case DatumTokyo:
+		return "Tokyo"
+	case DatumJGD2000:
+		return "JGD2000"
+	case DatumJGD2011:
+		return "JGD2011"
+	case DatumWGS84:
+		return "WGS84"
+	}
+	return "Unknown"
+}
+
+// Ellipsoid returns ellipsoid of the datum. WGS84 is returned for DatumUnknown.
+func (d Datum) Ellipsoid() *Ellipsoid {
+	switch d {
+	case DatumTokyo:
+		return Bessel1841
+	case DatumJGD2000, DatumJGD2011:
+		return GRS80
+	}
+	return WGS84
+}
+
+// crs returns CRS identifier for ISO6709.
+func (d Datum) crs() string {
+	switch d {
+	case DatumUnknown:
+		return ""
+	case DatumWGS84:
+		return "WGS_84"
+	}
+	return d.String()
+}
+
+// EPSG returns EPSG code of geographic 2D CRS, and 0 for DatumUnknown.
+func (d Datum) EPSG() int {
+	switch d {
+	case DatumTokyo:
+		return 4301
+	case DatumJGD2000:
+		return 4612
+	case DatumJGD2011:
+		return 6668
+	case DatumWGS84:
+		return 4326
+	}
+	return 0
+}
+
+func datumFromCRS(crs string) Datum {
+	switch crs {
+	case "WGS_84", "WGS84", "EPSG:4326":
+		return DatumWGS84
+	case "JGD2000", "EPSG:4612":
+		return DatumJGD2000
+	case "JGD2011", "EPSG:6668":
+		return DatumJGD2011
+	case "Tokyo", "TOKYO", "EPSG:4301":
+		return DatumTokyo
+	}
+	return DatumUnknown
+}
+
+// Datum is getter for datum.
+func (latlong Point) Datum() Datum {
+	return latlong.datum
+}
+
+// WithDatum returns latlong tagged by datum d without transformation.
+func (latlong Point) WithDatum(d Datum) Point {
+	latlong.datum = d
+	return latlong
+}
+
+// Helmert is 7-parameter Helmert transformation by position vector convention (EPSG:9606).
+type Helmert struct {
+	From, To   Datum
+	Tx, Ty, Tz float64 // translation in meters.
+	Rx, Ry, Rz float64 // rotation in arc-seconds.
+	Scale      float64 // scale difference in ppm.
+}
+
+// HelmertTokyoToJGD2000 is 3-parameter transformation from Tokyo Datum to JGD2000 by GSI.
+// The accuracy is a few meters. Use DatumGrid with TKY2JGD.par for better accuracy.
+var HelmertTokyoToJGD2000 = Helmert{From: DatumTokyo, To: DatumJGD2000, Tx: -146.414, Ty: 507.337, Tz: 680.507}
+
+// Inverse returns inverse transformation.
+func (h Helmert) Inverse() Helmert {
+	return Helmert{From: h.To, To: h.From,
+		Tx: -h.Tx, Ty: -h.Ty, Tz: -h.Tz,
+		Rx: -h.Rx, Ry: -h.Ry, Rz: -h.Rz,
+		Scale: -h.Scale}
+}
+
+// Transform transforms latlong from h.From to h.To.
+// Altitude is treated as ellipsoidal height, and 0 if nil.
+func (h Helmert) Transform(latlong Point) (Point, error) {
+	if latlong.datum != DatumUnknown && latlong.datum != h.From {
+		return latlong, errors.New("Datum mismatch " + latlong.datum.String() + " " + h.From.String())
+	}
+
+	var height float64
+	if latlong.alt != nil {
+		height = *latlong.alt
+	}
+	x, y, z := h.From.Ellipsoid().ecef(float64(latlong.lat.radian), float64(latlong.lng.radian), height)
+
+	const sec = math.Pi / 180 / 3600
+	s := 1 + h.Scale*1e-6
+	rx, ry, rz := h.Rx*sec, h.Ry*sec, h.Rz*sec
+	x, y, z = h.Tx+s*(x-rz*y+ry*z),
+		h.Ty+s*(rz*x+y-rx*z),
+		h.Tz+s*(-ry*x+rx*y+z)
+
+	lat, lng, height := h.To.Ellipsoid().geodetic(x, y, z)
+	latlong.lat.radian = s1.Angle(lat)
+	latlong.lng.radian = s1.Angle(lng)
+	if latlong.alt != nil {
+		latlong.alt = &height
+	}
+	latlong.datum = h.To
+	return latlong, nil
+}
+
+// TransformDatum transforms latlong to datum to.
+// JGD2000, JGD2011 and WGS84 are treated as identical (difference is less than 1m
+// except crustal deformation, use DatumGrid with PatchJGD for it),
+// and HelmertTokyoToJGD2000 is used for Tokyo Datum.
+func (latlong Point) TransformDatum(to Datum) (Point, error) {
+	from := latlong.datum
+	if from == DatumUnknown || to == DatumUnknown {
+		return latlong, errors.New("Unknown datum")
+	}
+	if from == to {
+		return latlong, nil
+	}
+	var err error
+	if from == DatumTokyo {
+		if latlong, err = HelmertTokyoToJGD2000.Transform(latlong); err != nil {
+			return latlong, err
+		}
+	}
+	if to == DatumTokyo {
+		return HelmertTokyoToJGD2000.Inverse().Transform(latlong.WithDatum(DatumJGD2000))
+	}
+	return latlong.WithDatum(to), nil
+}
+
+// ecef returns earth-centered earth-fixed coordinate in meters.
+func (e *Ellipsoid) ecef(lat, lng, height float64) (x, y, z float64) {
+	sinlat, coslat := math.Sincos(lat)
+	n := e.a / math.Sqrt(1-e.e2*sinlat*sinlat)
+	x = (n + height) * coslat * math.Cos(lng)
+	y = (n + height) * coslat * math.Sin(lng)
+	z = (n*(1-e.e2) + height) * sinlat
+	return
+}
+
+// geodetic returns latitude and longitude in radian and height in meters from ECEF.
+func (e *Ellipsoid) geodetic(x, y, z float64) (lat, lng, height float64) {
+	p := math.Hypot(x, y)
+	lng = math.Atan2(y, x)
+	lat = math.Atan2(z, p*(1-e.e2))
+	for i := 0; i < 10; i++ {
+		sinlat := math.Sin(lat)
+		n := e.a / math.Sqrt(1-e.e2*sinlat*sinlat)
+		height = p/math.Cos(lat) - n
+		lat = math.Atan2(z, p*(1-e.e2*n/(n+height)))
+	}
+	sinlat := math.Sin(lat)
+	height = p/math.Cos(lat) - e.a/math.Sqrt(1-e.e2*sinlat*sinlat)
+	return
+}
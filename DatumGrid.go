@@ -0,0 +1,143 @@
+package latlong
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/golang/geo/s1"
+)
+
+// DatumGrid is grid based datum transformation by par file of GSI,
+// such as TKY2JGD.par (Tokyo Datum to JGD2000) or touhokutaiheiyouoki2011.par of PatchJGD (JGD2000 to JGD2011).
+// Shifts are on south-west corners of 3rd mesh of JIS X 0410 and bilinearly interpolated.
+type DatumGrid struct {
+	From, To Datum
+	shifts   map[[2]int]datumShift // key is 3rd mesh index of latitude and longitude.
+}
+
+type datumShift struct {
+	dlat, dlng float64 // arc-seconds
+	dh         float64 // meters
+}
+
+// LoadDatumGridPar loads par file from path.
+func LoadDatumGridPar(path string, from, to Datum) (*DatumGrid, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadDatumGridPar(f, from, to)
+}
+
+// ReadDatumGridPar reads par file which lines are "MeshCode dB(sec) dL(sec) [dH(m)]".
+// Header lines not beginning with 3rd mesh code are skipped.
+func ReadDatumGridPar(r io.Reader, from, to Datum) (*DatumGrid, error) {
+	g := &DatumGrid{From: from, To: to, shifts: make(map[[2]int]datumShift)}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || len(fields[0]) != JISMesh3 {
+			continue
+		}
+		lat, lng, _, err := jisMeshIndex(fields[0])
+		if err != nil {
+			continue
+		}
+		var s datumShift
+		if s.dlat, err = strconv.ParseFloat(fields[1], 64); err != nil {
+			return nil, err
+		}
+		if s.dlng, err = strconv.ParseFloat(fields[2], 64); err != nil {
+			return nil, err
+		}
+		if len(fields) >= 4 {
+			if s.dh, err = strconv.ParseFloat(fields[3], 64); err != nil {
+				return nil, err
+			}
+		}
+		g.shifts[[2]int{lat / 8, lng / 8}] = s
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(g.shifts) == 0 {
+		return nil, errors.New("No grid in par file")
+	}
+	return g, nil
+}
+
+// shift returns interpolated shift at lat, lng in degrees.
+func (g *DatumGrid) shift(lat, lng float64) (s datumShift, err error) {
+	y := lat * jisMeshLatUnits / 8
+	x := (lng - 100) * jisMeshLngUnits / 8
+	y0, x0 := math.Floor(y), math.Floor(x)
+	fy, fx := y-y0, x-x0
+
+	var corners [4]datumShift
+	for i := range corners {
+		var ok bool
+		if corners[i], ok = g.shifts[[2]int{int(y0) + i/2, int(x0) + i%2}]; !ok {
+			return s, errors.New("Out of datum grid")
+		}
+	}
+	bilinear := func(f func(datumShift) float64) float64 {
+		return (1-fy)*((1-fx)*f(corners[0])+fx*f(corners[1])) +
+			fy*((1-fx)*f(corners[2])+fx*f(corners[3]))
+	}
+	s.dlat = bilinear(func(s datumShift) float64 { return s.dlat })
+	s.dlng = bilinear(func(s datumShift) float64 { return s.dlng })
+	s.dh = bilinear(func(s datumShift) float64 { return s.dh })
+	return
+}
+
+func (s datumShift) apply(latlong Point, sign float64) Point {
+	latlong.lat.radian += s1.Angle(sign*s.dlat/3600) * s1.Degree
+	latlong.lng.radian += s1.Angle(sign*s.dlng/3600) * s1.Degree
+	if latlong.alt != nil {
+		h := *latlong.alt + sign*s.dh
+		latlong.alt = &h
+	}
+	return latlong
+}
+
+// Transform transforms latlong from g.From to g.To.
+func (g *DatumGrid) Transform(latlong Point) (Point, error) {
+	if latlong.datum != DatumUnknown && latlong.datum != g.From {
+		return latlong, errors.New("Datum mismatch " + latlong.datum.String() + " " + g.From.String())
+	}
+	s, err := g.shift(latlong.Lat().Degrees(), latlong.Lng().Degrees())
+	if err != nil {
+		return latlong, err
+	}
+	latlong = s.apply(latlong, 1)
+	latlong.datum = g.To
+	return latlong, nil
+}
+
+// Inverse transforms latlong from g.To to g.From by iteration.
+func (g *DatumGrid) Inverse(latlong Point) (Point, error) {
+	if latlong.datum != DatumUnknown && latlong.datum != g.To {
+		return latlong, errors.New("Datum mismatch " + latlong.datum.String() + " " + g.To.String())
+	}
+	p := latlong
+	for i := 0; i < 10; i++ {
+		s, err := g.shift(p.Lat().Degrees(), p.Lng().Degrees())
+		if err != nil {
+			return latlong, err
+		}
+		p0 := p
+		p = s.apply(latlong, -1)
+		if math.Abs(float64(p.lat.radian-p0.lat.radian)) < 1e-14 && math.Abs(float64(p.lng.radian-p0.lng.radian)) < 1e-14 {
+			break
+		}
+	}
+	p.datum = g.From
+	return p, nil
+}
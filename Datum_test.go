@@ -0,0 +1,112 @@
+package latlong_test
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	latlong "github.com/toyo/go-latlong"
+)
+
+func TestHelmertTokyoToJGD2000(t *testing.T) {
+	tokyo := latlong.NewPoint(latlong.NewAngle(35.6812, 0), latlong.NewAngle(139.7671, 0), nil).WithDatum(latlong.DatumTokyo)
+
+	jgd, err := latlong.HelmertTokyoToJGD2000.Transform(tokyo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jgd.Datum() != latlong.DatumJGD2000 {
+		t.Errorf("expected JGD2000, was %v", jgd.Datum())
+	}
+	// Tokyo Datum is about 450m north-west of JGD2000 around Tokyo.
+	if d := float64(tokyo.DistanceEllipsoidKm(&jgd)); d < 0.4 || d > 0.5 {
+		t.Errorf("shift %v km", d)
+	}
+	if !(jgd.Lat().Degrees() > tokyo.Lat().Degrees() && jgd.Lng().Degrees() < tokyo.Lng().Degrees()) {
+		t.Errorf("shift direction %v %v", tokyo, jgd)
+	}
+
+	back, err := latlong.HelmertTokyoToJGD2000.Inverse().Transform(jgd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// height change is dropped as altitude is nil.
+	if d := float64(tokyo.DistanceEllipsoidKm(&back)); d > 1e-5 {
+		t.Errorf("round trip error %v km", d)
+	}
+
+	if _, err := latlong.HelmertTokyoToJGD2000.Transform(jgd); err == nil {
+		t.Error("expected datum mismatch error")
+	}
+
+	p, err := tokyo.TransformDatum(latlong.DatumWGS84)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Datum() != latlong.DatumWGS84 || p.Lat() != jgd.Lat() || p.Lng() != jgd.Lng() {
+		t.Errorf("expected %v, was %v", jgd, p)
+	}
+}
+
+func TestDatumISO6709(t *testing.T) {
+	p, err := latlong.ParseISO6709([]byte(`+35.68+139.77CRSJGD2011/`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Datum() != latlong.DatumJGD2011 {
+		t.Errorf("expected JGD2011, was %v", p.Datum())
+	}
+	b, _ := p.MarshalText()
+	if string(b) != `+35.68+139.77CRSJGD2011/` {
+		t.Errorf("was %s", b)
+	}
+}
+
+func TestDatumGrid(t *testing.T) {
+	par := `JGD2000 to JGD2011 test
+MeshCode   dB(sec)   dL(sec)  dH(m)
+53394611   1.00000   -2.00000  0.100
+53394612   1.00000   -2.00000  0.100
+53394621   3.00000   -4.00000  0.300
+53394622   3.00000   -4.00000  0.300
+`
+	path := filepath.Join(t.TempDir(), "test.par")
+	if err := os.WriteFile(path, []byte(par), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	g, err := latlong.LoadDatumGridPar(path, latlong.DatumJGD2000, latlong.DatumJGD2011)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// center of 53394611
+	rect, _ := latlong.NewRectJISMesh("53394611")
+	alt := 10.0
+	p := latlong.NewPoint(rect.Center().Lat(), rect.Center().Lng(), &alt).WithDatum(latlong.DatumJGD2000)
+	p1, err := g.Transform(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := (p1.Lat().Degrees() - p.Lat().Degrees()) * 3600; math.Abs(d-2) > 1e-6 {
+		t.Errorf("dB expected 2, was %v", d)
+	}
+	if d := (p1.Lng().Degrees() - p.Lng().Degrees()) * 3600; math.Abs(d+3) > 1e-6 {
+		t.Errorf("dL expected -3, was %v", d)
+	}
+	if math.Abs(*p1.Alt()-10.2) > 1e-9 || p1.Datum() != latlong.DatumJGD2011 {
+		t.Errorf("was %v %v", *p1.Alt(), p1.Datum())
+	}
+
+	p2, err := g.Inverse(p1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := float64(p.DistanceEllipsoidKm(&p2)); d > 1e-6 || p2.Datum() != latlong.DatumJGD2000 {
+		t.Errorf("round trip error %v km %v", d, p2.Datum())
+	}
+
+	if _, err := g.Transform(latlong.NewPoint(latlong.NewAngle(35, 0), latlong.NewAngle(135, 0), nil)); err == nil {
+		t.Error("expected out of grid error")
+	}
+}
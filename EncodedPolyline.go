@@ -0,0 +1,127 @@
+package latlong
+
+import (
+	"errors"
+	"math"
+	"strconv"
+)
+
+// EncodedPolyline returns Encoded Polyline Algorithm Format of Google.
+// precision is number of decimal digits, 5 for Google and 6 for OSRM or Valhalla.
+func (cds LineString) EncodedPolyline(precision int) string {
+	return string(cds.appendEncodedPolyline(nil, precision, -1))
+}
+
+// EncodedPolylineAlt returns Encoded Polyline with altitude as third dimension.
+// altprecision is number of decimal digits of altitude in meters. Altitude is 0 if nil.
+func (cds LineString) EncodedPolylineAlt(precision, altprecision int) string {
+	return string(cds.appendEncodedPolyline(nil, precision, altprecision))
+}
+
+func (cds LineString) appendEncodedPolyline(b []byte, precision, altprecision int) []byte {
+	factor := math.Pow10(precision)
+	altfactor := math.Pow10(altprecision)
+	var lat, lng, alt int64
+	for _, p := range cds.MultiPoint {
+		lat1 := int64(math.Round(p.lat.Degrees() * factor))
+		lng1 := int64(math.Round(p.lng.Degrees() * factor))
+		b = appendPolylineValue(b, lat1-lat)
+		b = appendPolylineValue(b, lng1-lng)
+		lat, lng = lat1, lng1
+		if altprecision >= 0 {
+			var alt1 int64
+			if p.alt != nil {
+				alt1 = int64(math.Round(*p.alt * altfactor))
+			}
+			b = appendPolylineValue(b, alt1-alt)
+			alt = alt1
+		}
+	}
+	return b
+}
+
+func appendPolylineValue(b []byte, v int64) []byte {
+	u := uint64(v) << 1
+	if v < 0 {
+		u = ^u
+	}
+	for u >= 0x20 {
+		b = append(b, byte(0x20|u&0x1f)+63)
+		u >>= 5
+	}
+	return append(b, byte(u)+63)
+}
+
+// NewLineStringFromEncodedPolyline is from Encoded Polyline Algorithm Format of Google.
+// precision is number of decimal digits, 5 for Google and 6 for OSRM or Valhalla.
+func NewLineStringFromEncodedPolyline(s string, precision int) (LineString, error) {
+	return newLineStringFromEncodedPolyline(s, precision, -1)
+}
+
+// NewLineStringFromEncodedPolylineAlt is from Encoded Polyline with altitude as third dimension.
+func NewLineStringFromEncodedPolylineAlt(s string, precision, altprecision int) (LineString, error) {
+	if altprecision < 0 {
+		return LineString{}, errors.New("Encoded Polyline altitude precision error")
+	}
+	return newLineStringFromEncodedPolyline(s, precision, altprecision)
+}
+
+func newLineStringFromEncodedPolyline(s string, precision, altprecision int) (ls LineString, err error) {
+	if precision < 0 || precision > 10 || altprecision > 10 {
+		return ls, errors.New("Encoded Polyline precision error")
+	}
+	factor := math.Pow10(precision)
+	altfactor := math.Pow10(altprecision)
+	var lat, lng, alt int64
+	for i := 0; i < len(s); {
+		var d int64
+		if d, i, err = polylineValue(s, i); err != nil {
+			return
+		}
+		lat += d
+		if d, i, err = polylineValue(s, i); err != nil {
+			return
+		}
+		lng += d
+		var p Point
+		p.lat = AngleFromBytes(strconv.AppendFloat(nil, float64(lat)/factor, 'f', precision, 64))
+		p.lng = AngleFromBytes(strconv.AppendFloat(nil, float64(lng)/factor, 'f', precision, 64))
+		if isErrorDeg(p.lat) || isErrorDeg(p.lng) || math.Abs(p.lat.Degrees()) > 90 || math.Abs(p.lng.Degrees()) > 180 {
+			return LineString{}, errors.New("Encoded Polyline out of range")
+		}
+		if altprecision >= 0 {
+			if d, i, err = polylineValue(s, i); err != nil {
+				return
+			}
+			alt += d
+			altitude := float64(alt) / altfactor
+			p.alt = &altitude
+		}
+		ls.MultiPoint = append(ls.MultiPoint, p)
+	}
+	return
+}
+
+// polylineValue returns value at s[i:] and next index.
+func polylineValue(s string, i int) (v int64, next int, err error) {
+	var u uint64
+	for shift := uint(0); ; shift += 5 {
+		if i >= len(s) || shift > 60 {
+			return 0, i, errors.New("Encoded Polyline is truncated")
+		}
+		c := int(s[i]) - 63
+		i++
+		if c < 0 || c >= 0x40 {
+			return 0, i, errors.New("Encoded Polyline invalid character")
+		}
+		u |= uint64(c&0x1f) << shift
+		if c < 0x20 {
+			break
+		}
+	}
+	v = int64(u >> 1)
+	if u&1 != 0 {
+		v = ^v
+	}
+	return v, i, nil
+}
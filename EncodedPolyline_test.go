@@ -0,0 +1,61 @@
+package latlong_test
+
+import (
+	"testing"
+
+	latlong "github.com/toyo/go-latlong"
+)
+
+func TestEncodedPolyline(t *testing.T) {
+	ls := latlong.LineString{MultiPoint: latlong.MultiPoint{
+		latlong.NewPoint(latlong.NewAngle(38.5, 0), latlong.NewAngle(-120.2, 0), nil),
+		latlong.NewPoint(latlong.NewAngle(40.7, 0), latlong.NewAngle(-120.95, 0), nil),
+		latlong.NewPoint(latlong.NewAngle(43.252, 0), latlong.NewAngle(-126.453, 0), nil),
+	}}
+
+	expct := "_p~iF~ps|U_ulLnnqC_mqNvxq`@"
+	if s := ls.EncodedPolyline(5); s != expct {
+		t.Errorf("expected %s, was %s", expct, s)
+	}
+	ls1, err := latlong.NewLineStringFromEncodedPolyline(expct, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ls1.MultiPoint) != 3 || ls1.MultiPoint[2].Lat().Degrees() != 43.252 || ls1.MultiPoint[2].Lng().Degrees() != -126.453 {
+		t.Errorf("was %v", ls1)
+	}
+	if s := ls1.MultiPoint[1].Lng().String(); s != "-120.95000" {
+		t.Errorf("expected -120.95000, was %s", s)
+	}
+
+	s6 := ls.EncodedPolyline(6)
+	if ls6, err := latlong.NewLineStringFromEncodedPolyline(s6, 6); err != nil || ls6.EncodedPolyline(6) != s6 || ls6.MultiPoint[1].Lat().Degrees() != 40.7 {
+		t.Errorf("was %v %v", ls6, err)
+	}
+
+	for _, s := range []string{"_p~iF", "_p~iF~ps|U_", "_p~iF ps|U"} {
+		if _, err := latlong.NewLineStringFromEncodedPolyline(s, 5); err == nil {
+			t.Errorf("expected error %q", s)
+		}
+	}
+}
+
+func TestEncodedPolylineAlt(t *testing.T) {
+	alt0, alt1 := 2305.0, 3776.5
+	ls := latlong.LineString{MultiPoint: latlong.MultiPoint{
+		latlong.NewPoint(latlong.NewAngle(35.3950, 0), latlong.NewAngle(138.7330, 0), &alt0),
+		latlong.NewPoint(latlong.NewAngle(35.3606, 0), latlong.NewAngle(138.7274, 0), &alt1),
+	}}
+	s := ls.EncodedPolylineAlt(6, 1)
+	ls1, err := latlong.NewLineStringFromEncodedPolylineAlt(s, 6, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ls1.MultiPoint) != 2 || *ls1.MultiPoint[0].Alt() != alt0 || *ls1.MultiPoint[1].Alt() != alt1 ||
+		ls1.MultiPoint[1].Lat().Degrees() != 35.3606 {
+		t.Errorf("was %v", ls1)
+	}
+	if _, err := latlong.NewLineStringFromEncodedPolylineAlt(s, 6, -1); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,375 @@
+package latlong
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GPX is GPS Exchange Format 1.1.
+// https://www.topografix.com/GPX/1/1/
+type GPX struct {
+	Creator   string
+	Name      string
+	Waypoints []GPXPoint
+	Routes    []GPXRoute
+	Tracks    []GPXTrack
+}
+
+// GPXPoint is wpt, rtept or trkpt. Elevation is in alt of Point.
+type GPXPoint struct {
+	Point
+	Time time.Time // zero if not specified.
+	Name string
+	Desc string
+}
+
+// GPXRoute is rte.
+type GPXRoute struct {
+	Name   string
+	Desc   string
+	Points []GPXPoint
+}
+
+// GPXTrack is trk.
+type GPXTrack struct {
+	Name     string
+	Desc     string
+	Segments []GPXSegment
+}
+
+// GPXSegment is trkseg.
+type GPXSegment []GPXPoint
+
+const gpxNamespace = "http://www.topografix.com/GPX/1/1"
+
+type gpxXML struct {
+	XMLName   xml.Name     `xml:"gpx"`
+	Xmlns     string       `xml:"xmlns,attr,omitempty"`
+	Version   string       `xml:"version,attr"`
+	Creator   string       `xml:"creator,attr"`
+	Metadata  *gpxMetadata `xml:"metadata,omitempty"`
+	Waypoints []gpxPoint   `xml:"wpt"`
+	Routes    []gpxRoute   `xml:"rte"`
+	Tracks    []gpxTrack   `xml:"trk"`
+}
+
+type gpxMetadata struct {
+	Name string `xml:"name,omitempty"`
+}
+
+type gpxPoint struct {
+	Lat  string     `xml:"lat,attr"`
+	Lon  string     `xml:"lon,attr"`
+	Ele  *float64   `xml:"ele,omitempty"`
+	Time *time.Time `xml:"time,omitempty"`
+	Name string     `xml:"name,omitempty"`
+	Desc string     `xml:"desc,omitempty"`
+}
+
+type gpxRoute struct {
+	Name   string     `xml:"name,omitempty"`
+	Desc   string     `xml:"desc,omitempty"`
+	Points []gpxPoint `xml:"rtept"`
+}
+
+type gpxTrack struct {
+	Name     string       `xml:"name,omitempty"`
+	Desc     string       `xml:"desc,omitempty"`
+	Segments []gpxSegment `xml:"trkseg"`
+}
+
+type gpxSegment struct {
+	Points []gpxPoint `xml:"trkpt"`
+}
+
+// ReadGPX decodes GPX from r.
+func ReadGPX(r io.Reader) (*GPX, error) {
+	var g GPX
+	if err := xml.NewDecoder(r).Decode(&g); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+// Write encodes g to w as GPX 1.1 with XML header.
+func (g *GPX) Write(w io.Writer) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", " ")
+	if err := enc.Encode(g); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// MarshalXML is a marshaler for XML.
+func (g GPX) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	x := gpxXML{Xmlns: gpxNamespace, Version: "1.1", Creator: g.Creator}
+	if x.Creator == "" {
+		x.Creator = "github.com/toyo/go-latlong"
+	}
+	if g.Name != "" {
+		x.Metadata = &gpxMetadata{Name: g.Name}
+	}
+	for _, p := range g.Waypoints {
+		x.Waypoints = append(x.Waypoints, p.gpxPoint())
+	}
+	for _, r := range g.Routes {
+		xr := gpxRoute{Name: r.Name, Desc: r.Desc}
+		for _, p := range r.Points {
+			xr.Points = append(xr.Points, p.gpxPoint())
+		}
+		x.Routes = append(x.Routes, xr)
+	}
+	for _, t := range g.Tracks {
+		xt := gpxTrack{Name: t.Name, Desc: t.Desc}
+		for _, s := range t.Segments {
+			var xs gpxSegment
+			for _, p := range s {
+				xs.Points = append(xs.Points, p.gpxPoint())
+			}
+			xt.Segments = append(xt.Segments, xs)
+		}
+		x.Tracks = append(x.Tracks, xt)
+	}
+	return e.EncodeElement(&x, xml.StartElement{Name: xml.Name{Local: "gpx"}})
+}
+
+// UnmarshalXML is a unmarshaler for XML.
+func (g *GPX) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var x gpxXML
+	if err := d.DecodeElement(&x, &start); err != nil {
+		return err
+	}
+	if start.Name.Local != "gpx" {
+		return errors.New("GPX root element is " + start.Name.Local)
+	}
+
+	*g = GPX{Creator: x.Creator}
+	if x.Metadata != nil {
+		g.Name = x.Metadata.Name
+	}
+	for _, xp := range x.Waypoints {
+		p, err := xp.point()
+		if err != nil {
+			return err
+		}
+		g.Waypoints = append(g.Waypoints, p)
+	}
+	for _, xr := range x.Routes {
+		r := GPXRoute{Name: xr.Name, Desc: xr.Desc}
+		for _, xp := range xr.Points {
+			p, err := xp.point()
+			if err != nil {
+				return err
+			}
+			r.Points = append(r.Points, p)
+		}
+		g.Routes = append(g.Routes, r)
+	}
+	for _, xt := range x.Tracks {
+		t := GPXTrack{Name: xt.Name, Desc: xt.Desc}
+		for _, xs := range xt.Segments {
+			var s GPXSegment
+			for _, xp := range xs.Points {
+				p, err := xp.point()
+				if err != nil {
+					return err
+				}
+				s = append(s, p)
+			}
+			t.Segments = append(t.Segments, s)
+		}
+		g.Tracks = append(g.Tracks, t)
+	}
+	return nil
+}
+
+func (p GPXPoint) gpxPoint() (x gpxPoint) {
+	x.Lat = p.lat.String()
+	x.Lon = p.lng.String()
+	x.Ele = p.alt
+	if !p.Time.IsZero() {
+		t := p.Time.UTC()
+		x.Time = &t
+	}
+	x.Name = p.Name
+	x.Desc = p.Desc
+	return
+}
+
+func (x gpxPoint) point() (p GPXPoint, err error) {
+	p.lat = AngleFromBytes([]byte(x.Lat))
+	p.lng = AngleFromBytes([]byte(x.Lon))
+	if isErrorDeg(p.lat) || isErrorDeg(p.lng) || x.Lat == "" || x.Lon == "" {
+		return p, errors.New("GPX lat or lon error " + strconv.Quote(x.Lat) + " " + strconv.Quote(x.Lon))
+	}
+	p.alt = x.Ele
+	p.datum = DatumWGS84
+	if x.Time != nil {
+		p.Time = *x.Time
+	}
+	p.Name = strings.TrimSpace(x.Name)
+	p.Desc = strings.TrimSpace(x.Desc)
+	return
+}
+
+type gpxPointJSON struct {
+	Coordinates Point      `json:"coordinates"`
+	Time        *time.Time `json:"time,omitempty"`
+	Name        string     `json:"name,omitempty"`
+	Desc        string     `json:"desc,omitempty"`
+}
+
+// MarshalJSON is a marshaler for JSON, not to lose time, name and desc by Point.MarshalJSON.
+func (p GPXPoint) MarshalJSON() ([]byte, error) {
+	x := gpxPointJSON{Coordinates: p.Point, Name: p.Name, Desc: p.Desc}
+	if !p.Time.IsZero() {
+		x.Time = &p.Time
+	}
+	return json.Marshal(&x)
+}
+
+// UnmarshalJSON is a unmarshaler for JSON.
+func (p *GPXPoint) UnmarshalJSON(data []byte) error {
+	var x gpxPointJSON
+	if err := json.Unmarshal(data, &x); err != nil {
+		return err
+	}
+	*p = GPXPoint{Point: x.Coordinates, Name: x.Name, Desc: x.Desc}
+	if x.Time != nil {
+		p.Time = *x.Time
+	}
+	return nil
+}
+
+// LineString returns LineString of the segment.
+func (s GPXSegment) LineString() LineString {
+	var ls LineString
+	for _, p := range s {
+		ls.MultiPoint = append(ls.MultiPoint, p.Point)
+	}
+	return ls
+}
+
+// Times returns timestamps of points of the segment.
+func (s GPXSegment) Times() []time.Time {
+	ts := make([]time.Time, len(s))
+	for i := range s {
+		ts[i] = s[i].Time
+	}
+	return ts
+}
+
+// NewGPXSegment is from LineString and timestamps. times may be nil or shorter than ls.
+func NewGPXSegment(ls LineString, times []time.Time) GPXSegment {
+	s := make(GPXSegment, len(ls.MultiPoint))
+	for i := range s {
+		s[i].Point = ls.MultiPoint[i]
+		if i < len(times) {
+			s[i].Time = times[i]
+		}
+	}
+	return s
+}
+
+// LineString returns LineString of the route.
+func (r GPXRoute) LineString() LineString {
+	return GPXSegment(r.Points).LineString()
+}
+
+// MultiLineString returns MultiLineString of segments of the track.
+func (t GPXTrack) MultiLineString() MultiLineString {
+	mls := make(MultiLineString, len(t.Segments))
+	for i := range t.Segments {
+		mls[i] = t.Segments[i].LineString()
+	}
+	return mls
+}
+
+// gpxProperties returns properties of Feature.
+func gpxProperties(typ, name, desc string) map[string]interface{} {
+	prop := map[string]interface{}{"gpxType": typ}
+	if name != "" {
+		prop["name"] = name
+	}
+	if desc != "" {
+		prop["desc"] = desc
+	}
+	return prop
+}
+
+// gpxTimes returns timestamps in RFC3339, or nil if all are zero.
+func gpxTimes(s GPXSegment) []string {
+	var ts []string
+	for _, p := range s {
+		if p.Time.IsZero() {
+			ts = append(ts, "")
+		} else {
+			ts = append(ts, p.Time.UTC().Format(time.RFC3339Nano))
+		}
+	}
+	if strings.Join(ts, "") == "" {
+		return nil
+	}
+	return ts
+}
+
+// GeoJSONFeatureCollection converts waypoints to Point, routes to LineString and tracks to LineString
+// (or MultiLineString if it has multiple segments).
+// Timestamps are in "time" of waypoints and "coordTimes" of routes and tracks.
+func (g *GPX) GeoJSONFeatureCollection() *GeoJSONFeatureCollection {
+	fc := NewGeoJSONFeatureCollection()
+	for _, p := range g.Waypoints {
+		prop := gpxProperties("wpt", p.Name, p.Desc)
+		if !p.Time.IsZero() {
+			prop["time"] = p.Time.UTC().Format(time.RFC3339Nano)
+		}
+		fc.Features = append(fc.Features, *p.Point.NewGeoJSONFeature(prop))
+	}
+	for _, r := range g.Routes {
+		prop := gpxProperties("rte", r.Name, r.Desc)
+		if ts := gpxTimes(r.Points); ts != nil {
+			prop["coordTimes"] = ts
+		}
+		fc.Features = append(fc.Features, *r.LineString().NewGeoJSONFeature(prop))
+	}
+	for _, t := range g.Tracks {
+		prop := gpxProperties("trk", t.Name, t.Desc)
+		coordtimes := make([][]string, len(t.Segments))
+		hastime := false
+		for i, s := range t.Segments {
+			coordtimes[i] = gpxTimes(s)
+			hastime = hastime || coordtimes[i] != nil
+		}
+		if len(t.Segments) == 1 {
+			if hastime {
+				prop["coordTimes"] = coordtimes[0]
+			}
+			fc.Features = append(fc.Features, *t.Segments[0].LineString().NewGeoJSONFeature(prop))
+		} else {
+			if hastime {
+				prop["coordTimes"] = coordtimes
+			}
+			fc.Features = append(fc.Features, *t.MultiLineString().NewGeoJSONFeature(prop))
+		}
+	}
+	return fc
+}
+
+// ReadGPXGeoJSON decodes GPX from r and converts it to GeoJSONFeatureCollection.
+func ReadGPXGeoJSON(r io.Reader) (*GeoJSONFeatureCollection, error) {
+	g, err := ReadGPX(r)
+	if err != nil {
+		return nil, err
+	}
+	return g.GeoJSONFeatureCollection(), nil
+}
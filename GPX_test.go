@@ -0,0 +1,109 @@
+package latlong_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	latlong "github.com/toyo/go-latlong"
+)
+
+const gpxstring = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="test" xmlns="http://www.topografix.com/GPX/1/1">
+ <metadata><name>Mt. Fuji</name></metadata>
+ <wpt lat="35.3606" lon="138.7274">
+  <ele>3776</ele>
+  <time>2021-07-01T04:30:00Z</time>
+  <name>Summit</name>
+ </wpt>
+ <rte>
+  <name>Route</name>
+  <rtept lat="35.3340" lon="138.7360"></rtept>
+  <rtept lat="35.3606" lon="138.7274"></rtept>
+ </rte>
+ <trk>
+  <name>Yoshida</name>
+  <trkseg>
+   <trkpt lat="35.3950" lon="138.7330"><ele>2305</ele><time>2021-07-01T00:00:00Z</time></trkpt>
+   <trkpt lat="35.3606" lon="138.7274"><ele>3776</ele><time>2021-07-01T04:30:00Z</time></trkpt>
+  </trkseg>
+ </trk>
+</gpx>`
+
+func TestGPX(t *testing.T) {
+	g, err := latlong.ReadGPX(strings.NewReader(gpxstring))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Name != "Mt. Fuji" || len(g.Waypoints) != 1 || len(g.Routes) != 1 || len(g.Tracks) != 1 {
+		t.Fatalf("was %#v", g)
+	}
+	wpt := g.Waypoints[0]
+	if wpt.Name != "Summit" || wpt.Alt() == nil || *wpt.Alt() != 3776 || wpt.Lat().Degrees() != 35.3606 ||
+		!wpt.Time.Equal(time.Date(2021, 7, 1, 4, 30, 0, 0, time.UTC)) {
+		t.Errorf("was %#v", wpt)
+	}
+	ls := g.Tracks[0].Segments[0].LineString()
+	if len(ls.MultiPoint) != 2 || *ls.MultiPoint[0].Alt() != 2305 {
+		t.Errorf("was %v", ls)
+	}
+	if ts := g.Tracks[0].Segments[0].Times(); ts[1].Sub(ts[0]) != 270*time.Minute {
+		t.Errorf("was %v", ts)
+	}
+
+	// round trip
+	var b bytes.Buffer
+	if err := g.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	g1, err := latlong.ReadGPX(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g1.Tracks[0].MultiLineString().Equal(g.Tracks[0].MultiLineString()) ||
+		!g1.Routes[0].LineString().Equal(g.Routes[0].LineString()) ||
+		!g1.Waypoints[0].Equal(g.Waypoints[0].Point) || !g1.Waypoints[0].Time.Equal(wpt.Time) {
+		t.Errorf("round trip mismatch %s", b.String())
+	}
+
+	j, err := json.Marshal(wpt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wpt1 latlong.GPXPoint
+	if err := json.Unmarshal(j, &wpt1); err != nil || wpt1.Name != "Summit" || !wpt1.Time.Equal(wpt.Time) ||
+		wpt1.Lat() != wpt.Lat() || *wpt1.Alt() != 3776 {
+		t.Errorf("was %s %v", j, err)
+	}
+
+	if _, err := latlong.ReadGPX(strings.NewReader(`<gpx><wpt lat="x" lon="1"/></gpx>`)); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestGPXGeoJSON(t *testing.T) {
+	fc, err := latlong.ReadGPXGeoJSON(strings.NewReader(gpxstring))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fc.Features) != 3 {
+		t.Fatalf("was %d features", len(fc.Features))
+	}
+	for i, typ := range []string{"Point", "LineString", "LineString"} {
+		if fc.Features[i].Geometry.Geo().Type() != typ {
+			t.Errorf("expected %s, was %s", typ, fc.Features[i].Geometry.Geo().Type())
+		}
+	}
+
+	b, err := json.Marshal(fc.Features[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	expct := `{"type":"Feature","geometry":{"type":"LineString","coordinates":[[138.7330,35.3950,2305],[138.7274,35.3606,3776]]},` +
+		`"properties":{"coordTimes":["2021-07-01T00:00:00Z","2021-07-01T04:30:00Z"],"gpxType":"trk","name":"Yoshida"}}`
+	if string(b) != expct {
+		t.Errorf("expected %s, was %s", expct, string(b))
+	}
+}
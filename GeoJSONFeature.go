@@ -1,8 +1,166 @@
 package latlong
 
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
 // GeoJSONFeature is Feature of GeoJSON
 type GeoJSONFeature struct {
-	Type     string           `json:"type"`
-	Geometry *GeoJSONGeometry `json:"geometry"`
-	Property interface{}      `json:"properties"`
+	Type     string                     `json:"type"`
+	ID       interface{}                `json:"id,omitempty"`   // string or json.Number.
+	BBox     []float64                  `json:"bbox,omitempty"` // [west, south, east, north]. west > east if it crosses antimeridian.
+	Geometry *GeoJSONGeometry           `json:"geometry"`
+	Property interface{}                `json:"properties"`
+	Foreign  map[string]json.RawMessage `json:"-"` // foreign members.
+}
+
+// MarshalJSON is a marshaler for JSON.
+func (f GeoJSONFeature) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(`{"type":`)
+	if err := geojsonMember(&b, f.Type); err != nil {
+		return nil, err
+	}
+	if f.ID != nil {
+		b.WriteString(`,"id":`)
+		if err := geojsonMember(&b, f.ID); err != nil {
+			return nil, err
+		}
+	}
+	if f.BBox != nil {
+		b.WriteString(`,"bbox":`)
+		if err := geojsonMember(&b, f.BBox); err != nil {
+			return nil, err
+		}
+	}
+	b.WriteString(`,"geometry":`)
+	if err := geojsonMember(&b, f.Geometry); err != nil {
+		return nil, err
+	}
+	b.WriteString(`,"properties":`)
+	if err := geojsonMember(&b, f.Property); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(f.Foreign))
+	for k := range f.Foreign {
+		switch k {
+		case "type", "id", "bbox", "geometry", "properties":
+		default:
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		b.WriteByte(',')
+		if err := geojsonMember(&b, k); err != nil {
+			return nil, err
+		}
+		b.WriteByte(':')
+		if err := geojsonMember(&b, f.Foreign[k]); err != nil {
+			return nil, err
+		}
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+func geojsonMember(b *bytes.Buffer, v interface{}) error {
+	js, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	b.Write(js)
+	return nil
+}
+
+// UnmarshalJSON is a unmarshaler for JSON. Unknown members are kept in Foreign.
+func (f *GeoJSONFeature) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	var ff GeoJSONFeature
+	for k, v := range members {
+		var err error
+		switch k {
+		case "type":
+			err = json.Unmarshal(v, &ff.Type)
+		case "id":
+			dec := json.NewDecoder(bytes.NewReader(v))
+			dec.UseNumber()
+			err = dec.Decode(&ff.ID)
+			switch ff.ID.(type) {
+			case string, json.Number, nil:
+			default:
+				err = errors.New("GeoJSON id must be string or number")
+			}
+		case "bbox":
+			err = json.Unmarshal(v, &ff.BBox)
+		case "geometry":
+			err = json.Unmarshal(v, &ff.Geometry)
+		case "properties":
+			err = json.Unmarshal(v, &ff.Property)
+		default:
+			if ff.Foreign == nil {
+				ff.Foreign = make(map[string]json.RawMessage)
+			}
+			ff.Foreign[k] = v
+		}
+		if err != nil {
+			return err
+		}
+	}
+	*f = ff
+	return nil
+}
+
+// DecodeProperties decodes properties into v, which is pointer to user type, as encoding/json.
+func (f GeoJSONFeature) DecodeProperties(v interface{}) error {
+	b, err := json.Marshal(f.Property)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// propertiesMap returns properties as map. It is nil if property is nil.
+func propertiesMap(property interface{}) (map[string]interface{}, error) {
+	switch p := property.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		return p, nil
+	}
+	b, err := json.Marshal(property)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, errors.New("GeoJSON properties must be JSON object")
+	}
+	return m, nil
+}
+
+// SetBBox sets BBox from RectBound of the geometry. BBox is nil if the geometry is null or empty.
+// BBox is not computed on MarshalJSON of a feature, because it may be stale after the geometry is modified.
+// GeoJSONFeatureCollection.AutoBBox computes it on encode.
+func (f *GeoJSONFeature) SetBBox() {
+	f.BBox = nil
+	if f.Geometry == nil || f.Geometry.Geo() == nil {
+		return
+	}
+	rect := f.Geometry.S2Region().RectBound()
+	if rect.IsEmpty() {
+		return
+	}
+	lo, hi := rect.Lo(), rect.Hi()
+	f.BBox = []float64{
+		roundDegrees(lo.Lng.Degrees()), roundDegrees(lo.Lat.Degrees()),
+		roundDegrees(hi.Lng.Degrees()), roundDegrees(hi.Lat.Degrees()),
+	}
 }
@@ -1,9 +1,35 @@
 package latlong
 
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+)
+
 // GeoJSONFeatureCollection is FeatureCollection of GeoJSON
 type GeoJSONFeatureCollection struct {
 	Type     string           `json:"type"`
 	Features []GeoJSONFeature `json:"features"`
+	AutoBBox bool             `json:"-"` // bbox of features without BBox is computed by SetBBox on MarshalJSON.
+}
+
+// MarshalJSON is a marshaler for JSON. Features are not modified by AutoBBox.
+func (g GeoJSONFeatureCollection) MarshalJSON() ([]byte, error) {
+	type collection GeoJSONFeatureCollection
+	c := collection(g)
+	if g.AutoBBox {
+		c.Features = make([]GeoJSONFeature, len(g.Features))
+		for i, f := range g.Features {
+			if f.BBox == nil {
+				f.SetBBox()
+			}
+			c.Features[i] = f
+		}
+	}
+	return json.Marshal(&c)
 }
 
 // NewGeoJSONFeatureCollection creates GeoJSONFeatureCollection
@@ -13,9 +39,105 @@ func NewGeoJSONFeatureCollection() *GeoJSONFeatureCollection {
 	return &g
 }
 
-// AddFeature adds GeoJSONFeature
-func (g *GeoJSONFeatureCollection) AddFeature(f GeoJSONFeature) *GeoJSONFeatureCollection {
+// AddFeature appends GeoJSONFeature to g, and returns g.
+func (g *GeoJSONFeatureCollection) AddFeature(f ...GeoJSONFeature) *GeoJSONFeatureCollection {
+	g.Features = append(g.Features, f...)
+	return g
+}
+
+// Merge appends features of collections to g, and returns g.
+func (g *GeoJSONFeatureCollection) Merge(collections ...*GeoJSONFeatureCollection) *GeoJSONFeatureCollection {
+	for _, c := range collections {
+		g.Features = append(g.Features, c.Features...)
+	}
+	return g
+}
+
+// Filter returns new collection of features which f returns true.
+func (g *GeoJSONFeatureCollection) Filter(f func(GeoJSONFeature) bool) *GeoJSONFeatureCollection {
+	gg := NewGeoJSONFeatureCollection()
+	for i := range g.Features {
+		if f(g.Features[i]) {
+			gg.Features = append(gg.Features, g.Features[i])
+		}
+	}
+	return gg
+}
+
+// Map returns new collection of features which f returns for each feature.
+func (g *GeoJSONFeatureCollection) Map(f func(GeoJSONFeature) GeoJSONFeature) *GeoJSONFeatureCollection {
 	gg := NewGeoJSONFeatureCollection()
-	gg.Features = append(gg.Features, f)
+	for i := range g.Features {
+		gg.Features = append(gg.Features, f(g.Features[i]))
+	}
 	return gg
 }
+
+// FilterProperty returns new collection of features which property key is value.
+// Values are compared in JSON, so that 1 and 1.0 are same.
+func (g *GeoJSONFeatureCollection) FilterProperty(key string, value interface{}) *GeoJSONFeatureCollection {
+	v, err := json.Marshal(value)
+	if err != nil {
+		return NewGeoJSONFeatureCollection()
+	}
+	return g.Filter(func(f GeoJSONFeature) bool {
+		prop, err := propertiesMap(f.Property)
+		if err != nil {
+			return false
+		}
+		pv, ok := prop[key]
+		if !ok {
+			return false
+		}
+		b, err := json.Marshal(pv)
+		return err == nil && bytes.Equal(b, v)
+	})
+}
+
+// SelectIntersects returns new collection of features which intersect geom.
+func (g *GeoJSONFeatureCollection) SelectIntersects(geom Geometry) *GeoJSONFeatureCollection {
+	return g.Filter(func(f GeoJSONFeature) bool {
+		return f.Geometry != nil && Intersects(f.Geometry.Geo(), geom)
+	})
+}
+
+// SelectWithin returns new collection of features which are contained by geom.
+func (g *GeoJSONFeatureCollection) SelectWithin(geom Geometry) *GeoJSONFeatureCollection {
+	return g.Filter(func(f GeoJSONFeature) bool {
+		return f.Geometry != nil && Contains(geom, f.Geometry.Geo())
+	})
+}
+
+// SortByDistance sorts features of g by the shortest distance from latlong to geometry in place.
+// Features without geometry are at the end.
+func (g *GeoJSONFeatureCollection) SortByDistance(latlong Point) {
+	p := latlong.S2Point()
+	ds := make([]s1.Angle, len(g.Features))
+	idx := make([]int, len(g.Features))
+	for i, f := range g.Features {
+		idx[i] = i
+		ds[i] = s1.InfAngle()
+		if f.Geometry != nil {
+			ds[i] = geometryDistance(p, f.Geometry.Geo())
+		}
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return ds[idx[i]] < ds[idx[j]]
+	})
+	fs := make([]GeoJSONFeature, len(g.Features))
+	for i := range idx {
+		fs[i] = g.Features[idx[i]]
+	}
+	g.Features = fs
+}
+
+// Rect returns bounding Rect of all geometries. It is empty if there is no geometry.
+func (g *GeoJSONFeatureCollection) Rect() *Rect {
+	rect := &Rect{s2.EmptyRect()}
+	for _, f := range g.Features {
+		if f.Geometry != nil && f.Geometry.Geo() != nil {
+			rect.Rect = rect.Union(f.Geometry.S2Region().RectBound())
+		}
+	}
+	return rect
+}
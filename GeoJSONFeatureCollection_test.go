@@ -2,6 +2,8 @@ package latlong_test
 
 import (
 	"encoding/json"
+	"math"
+	"strings"
 	"testing"
 
 	latlong "github.com/toyo/go-latlong"
@@ -51,3 +53,113 @@ func TestGeoJSONFeatureCollection(t *testing.T) {
 
 	t.Logf("%#v", geom)
 }
+
+func newTestFeatureCollection(t *testing.T) *latlong.GeoJSONFeatureCollection {
+	jsonstring := `{"type":"FeatureCollection","features":[
+	{"type":"Feature","geometry":{"type":"Point","coordinates":[139.7454,35.6586]},"properties":{"name":"Tokyo Tower","kind":"tower"}},
+	{"type":"Feature","geometry":{"type":"Point","coordinates":[135.5063,34.6525]},"properties":{"name":"Tsutenkaku","kind":"tower"}},
+	{"type":"Feature","geometry":{"type":"LineString","coordinates":[[139.0,35.0],[140.0,36.0]]},"properties":{"name":"Line","kind":1}},
+	{"type":"Feature","geometry":null,"properties":{"name":"Null"}}
+	]}`
+	var fc latlong.GeoJSONFeatureCollection
+	if err := json.Unmarshal([]byte(jsonstring), &fc); err != nil {
+		t.Fatal(err)
+	}
+	return &fc
+}
+
+func featureNames(fc *latlong.GeoJSONFeatureCollection) (names []string) {
+	for _, f := range fc.Features {
+		names = append(names, f.Property.(map[string]interface{})["name"].(string))
+	}
+	return
+}
+
+func TestGeoJSONFeatureCollectionAPI(t *testing.T) {
+	fc := newTestFeatureCollection(t)
+
+	if names := featureNames(fc.FilterProperty("kind", "tower")); len(names) != 2 || names[1] != "Tsutenkaku" {
+		t.Errorf("was %v", names)
+	}
+	if names := featureNames(fc.FilterProperty("kind", 1.0)); len(names) != 1 || names[0] != "Line" {
+		t.Errorf("was %v", names)
+	}
+
+	upper := fc.Map(func(f latlong.GeoJSONFeature) latlong.GeoJSONFeature {
+		f.Property = map[string]interface{}{"name": strings.ToUpper(f.Property.(map[string]interface{})["name"].(string))}
+		return f
+	})
+	if names := featureNames(upper); len(names) != 4 || names[0] != "TOKYO TOWER" || names[3] != "NULL" {
+		t.Errorf("was %v", names)
+	}
+	if names := featureNames(fc); names[0] != "Tokyo Tower" {
+		t.Errorf("was %v", names)
+	}
+
+	kanto := latlong.NewPolygon(latlong.LineString{MultiPoint: latlong.MultiPoint{
+		latlong.NewPoint(latlong.NewAngle(35, 0), latlong.NewAngle(139, 0), nil),
+		latlong.NewPoint(latlong.NewAngle(35, 0), latlong.NewAngle(140.5, 0), nil),
+		latlong.NewPoint(latlong.NewAngle(36.5, 0), latlong.NewAngle(140.5, 0), nil),
+		latlong.NewPoint(latlong.NewAngle(36.5, 0), latlong.NewAngle(139, 0), nil),
+	}})
+	if names := featureNames(fc.SelectIntersects(kanto)); len(names) != 2 || names[0] != "Tokyo Tower" || names[1] != "Line" {
+		t.Errorf("was %v", names)
+	}
+	if names := featureNames(fc.SelectWithin(kanto)); len(names) != 1 || names[0] != "Tokyo Tower" {
+		t.Errorf("was %v", names)
+	}
+
+	fc.SortByDistance(latlong.NewPoint(latlong.NewAngle(34.7, 0), latlong.NewAngle(135.5, 0), nil))
+	if names := featureNames(fc); names[0] != "Tsutenkaku" || names[1] != "Line" || names[2] != "Tokyo Tower" || names[3] != "Null" {
+		t.Errorf("was %v", names)
+	}
+
+	rect := fc.Rect()
+	if lo, hi := rect.Lo(), rect.Hi(); lo.Lat.Degrees() > 34.6525 || lo.Lng.Degrees() > 135.5063 ||
+		hi.Lat.Degrees() < 36 || hi.Lng.Degrees() > 140.0001 {
+		t.Errorf("was %v", rect)
+	}
+	if !latlong.NewGeoJSONFeatureCollection().Rect().IsEmpty() {
+		t.Error("expected empty")
+	}
+
+	fc1 := latlong.NewGeoJSONFeatureCollection().AddFeature(fc.Features[0]).Merge(fc, fc)
+	if len(fc1.Features) != 9 {
+		t.Errorf("was %d features", len(fc1.Features))
+	}
+}
+
+func TestGeoJSONFeatureCollectionAutoBBox(t *testing.T) {
+	fc := latlong.NewGeoJSONFeatureCollection()
+	if err := json.Unmarshal([]byte(`{"type":"FeatureCollection","features":[
+	{"type":"Feature","geometry":{"type":"LineString","coordinates":[[179,0],[-179,1]]},"properties":null},
+	{"type":"Feature","bbox":[1,2,3,4],"geometry":{"type":"Point","coordinates":[139,35]},"properties":null},
+	{"type":"Feature","geometry":null,"properties":null}
+	]}`), fc); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(fc)
+	if err != nil || strings.Contains(string(b), `"bbox":[179`) {
+		t.Errorf("was %s %v", b, err)
+	}
+
+	fc.AutoBBox = true
+	b, err = json.Marshal(fc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fc1 latlong.GeoJSONFeatureCollection
+	if err := json.Unmarshal(b, &fc1); err != nil {
+		t.Fatal(err)
+	}
+	// west > east across the antimeridian.
+	if bb := fc1.Features[0].BBox; len(bb) != 4 || bb[0] != 179 || math.Abs(bb[1]) > 1e-9 || bb[2] != -179 || math.Abs(bb[3]-1) > 1e-9 {
+		t.Errorf("was %v", bb)
+	}
+	if bb := fc1.Features[1].BBox; len(bb) != 4 || bb[0] != 1 {
+		t.Errorf("was %v", bb)
+	}
+	if fc1.Features[2].BBox != nil || fc.Features[0].BBox != nil {
+		t.Errorf("was %s", b)
+	}
+}
@@ -0,0 +1,80 @@
+package latlong_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	latlong "github.com/toyo/go-latlong"
+)
+
+func TestGeoJSONFeature(t *testing.T) {
+	jsonstring := `{"type":"Feature","id":12345678901234567,"bbox":[138.7,35.3,138.8,35.4],` +
+		`"geometry":{"type":"Point","coordinates":[138.7274,35.3606]},"properties":{"name":"Fuji","height":3776},` +
+		`"title":"Mt. Fuji","links":[{"rel":"self"}]}`
+
+	var f latlong.GeoJSONFeature
+	if err := json.Unmarshal([]byte(jsonstring), &f); err != nil {
+		t.Fatal(err)
+	}
+	if id, ok := f.ID.(json.Number); !ok || id.String() != "12345678901234567" {
+		t.Errorf("was %#v", f.ID)
+	}
+	if len(f.BBox) != 4 || string(f.Foreign["title"]) != `"Mt. Fuji"` {
+		t.Errorf("was %#v", f)
+	}
+
+	b, err := json.Marshal(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expct := `{"type":"Feature","id":12345678901234567,"bbox":[138.7,35.3,138.8,35.4],` +
+		`"geometry":{"type":"Point","coordinates":[138.7274,35.3606]},"properties":{"height":3776,"name":"Fuji"},` +
+		`"links":[{"rel":"self"}],"title":"Mt. Fuji"}`
+	if string(b) != expct {
+		t.Errorf("expected %s, was %s", expct, string(b))
+	}
+
+	var prop struct {
+		Name   string `json:"name"`
+		Height int    `json:"height"`
+	}
+	if err := f.DecodeProperties(&prop); err != nil || prop.Name != "Fuji" || prop.Height != 3776 {
+		t.Errorf("was %#v %v", prop, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"type":"Feature","id":{},"geometry":null,"properties":null}`), &f); err == nil {
+		t.Error("expected error")
+	}
+	if err := json.Unmarshal([]byte(`{"type":"Feature","id":"a","geometry":null,"properties":null}`), &f); err != nil || f.ID != "a" || f.Geometry != nil {
+		t.Errorf("was %#v %v", f, err)
+	}
+}
+
+func TestGeoJSONFeatureSetBBox(t *testing.T) {
+	ls := latlong.LineString{MultiPoint: latlong.MultiPoint{
+		latlong.NewPoint(latlong.NewAngle(35, 0), latlong.NewAngle(139, 0), nil),
+		latlong.NewPoint(latlong.NewAngle(36, 0), latlong.NewAngle(140, 0), nil),
+	}}
+	f := ls.NewGeoJSONFeature(nil)
+	f.SetBBox()
+	if len(f.BBox) != 4 || f.BBox[0] != 139 || f.BBox[1] != 35 || f.BBox[2] != 140 || f.BBox[3] < 36 || f.BBox[3] > 36.01 {
+		t.Errorf("was %v", f.BBox)
+	}
+
+	// antimeridian
+	ls = latlong.LineString{MultiPoint: latlong.MultiPoint{
+		latlong.NewPoint(latlong.NewAngle(0, 0), latlong.NewAngle(179, 0), nil),
+		latlong.NewPoint(latlong.NewAngle(0, 0), latlong.NewAngle(-179, 0), nil),
+	}}
+	f = ls.NewGeoJSONFeature(nil)
+	f.SetBBox()
+	if len(f.BBox) != 4 || f.BBox[0] != 179 || f.BBox[2] != -179 {
+		t.Errorf("was %v", f.BBox)
+	}
+
+	f.Geometry = nil
+	f.SetBBox()
+	if f.BBox != nil {
+		t.Errorf("was %v", f.BBox)
+	}
+}
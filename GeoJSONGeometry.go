@@ -69,8 +69,9 @@ func (geom GeoJSONGeometry) String() string {
 func (geom GeoJSONGeometry) MarshalJSON() ([]byte, error) {
 	var js struct {
 		Type        string          `json:"type"`
-		Coordinates json.RawMessage `json:"coordinates"`
-		Radius      *float64        `json:"radius,omitempty"` // only for Circle, which is GeoJSON specification 1.1 and leter.
+		Coordinates json.RawMessage `json:"coordinates,omitempty"`
+		Geometries  json.RawMessage `json:"geometries,omitempty"` // only for GeometryCollection.
+		Radius      *float64        `json:"radius,omitempty"`     // only for Circle, which is GeoJSON specification 1.1 and leter.
 	}
 
 	var err error
@@ -79,7 +80,11 @@ func (geom GeoJSONGeometry) MarshalJSON() ([]byte, error) {
 	} else {
 		js.Type = "Null"
 	}
-	js.Coordinates, err = json.Marshal(geom.geo)
+	if js.Type == "GeometryCollection" {
+		js.Geometries, err = json.Marshal(geom.geo)
+	} else {
+		js.Coordinates, err = json.Marshal(geom.geo)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -94,6 +99,7 @@ func (geom *GeoJSONGeometry) UnmarshalJSON(data []byte) error {
 	var js struct {
 		Type        string          `json:"type"`
 		Coordinates json.RawMessage `json:"coordinates"`
+		Geometries  json.RawMessage `json:"geometries"`       // only for GeometryCollection.
 		Radius      *float64        `json:"radius,omitempty"` // only for Circle, which is GeoJSON specification 1.1 and leter.
 	}
 
@@ -102,16 +108,36 @@ func (geom *GeoJSONGeometry) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	switch js.Type {
+	case "GeometryCollection":
+		var p GeometryCollection
+		err := json.Unmarshal(js.Geometries, &p)
+		geom.geo = p
+		return err
+	case "MultiPolygon":
+		var p MultiPolygon
+		err := json.Unmarshal(js.Coordinates, &p)
+		geom.geo = p
+		return err
 	case "Polygon":
 		var p Polygon
 		err := json.Unmarshal(js.Coordinates, &p)
 		geom.geo = p
 		return err
+	case "MultiLineString":
+		var p MultiLineString
+		err := json.Unmarshal(js.Coordinates, &p)
+		geom.geo = p
+		return err
 	case "LineString":
 		var p LineString
 		err := json.Unmarshal(js.Coordinates, &p)
 		geom.geo = p
 		return err
+	case "MultiPoint":
+		var p MultiPoint
+		err := json.Unmarshal(js.Coordinates, &p)
+		geom.geo = p
+		return err
 	case "Point":
 		var p Point
 		err := json.Unmarshal(js.Coordinates, &p)
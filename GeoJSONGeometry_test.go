@@ -4,15 +4,23 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/golang/geo/s2"
 	latlong "github.com/toyo/go-latlong"
 )
 
-func TestGeoJSONGeometryLineString(t *testing.T) {
-	jsonstring := `{ 
+const geoJSONLineString = `{ 
 	"type": "LineString",
 	"coordinates": [ [100.0, 0.0], [101.0, 1.0] ]
 	}`
 
+const geoJSONPolygon = `{ "type": "Polygon",
+	"coordinates": [
+	  [ [100.0, 0.0], [101.0, 0.0], [101.0, 1.0], [100.0, 1.0], [100.0, 0.0] ] ]
+   }`
+
+func TestGeoJSONGeometryLineString(t *testing.T) {
+	jsonstring := geoJSONLineString
+
 	var geom latlong.GeoJSONGeometry
 	err := json.Unmarshal([]byte(jsonstring), &geom)
 	if err != nil {
@@ -50,10 +58,7 @@ func TestGeoJSONGeometryCircle(t *testing.T) {
 }
 
 func TestGeoJSONGeometryPolygon(t *testing.T) {
-	jsonstring := `{ "type": "Polygon",
-	"coordinates": [
-	  [ [100.0, 0.0], [101.0, 0.0], [101.0, 1.0], [100.0, 1.0], [100.0, 0.0] ] ]
-   }`
+	jsonstring := geoJSONPolygon
 
 	var geom latlong.GeoJSONGeometry
 	err := json.Unmarshal([]byte(jsonstring), &geom)
@@ -88,3 +93,84 @@ func TestGeoJSONGeometryPoint(t *testing.T) {
 		t.Errorf("Unmatched expct %#v got %#v", llj, ll1j)
 	}
 }
+
+var geoJSONMultiTests = []struct {
+	jsonstring string
+	in, out    s2.LatLng
+}{
+	{`{"type":"MultiPoint","coordinates":[[100,0],[101,1]]}`,
+		s2.LatLngFromDegrees(1, 101), s2.LatLngFromDegrees(0, 101)},
+	{`{"type":"MultiLineString","coordinates":[[[100,0],[101,1]],[[102,2],[103,3]]]}`,
+		s2.LatLngFromDegrees(2, 102), s2.LatLngFromDegrees(0, 103)},
+	{`{"type":"MultiPolygon","coordinates":[` +
+		`[[[102,2],[103,2],[103,3],[102,3],[102,2]]],` +
+		`[[[100,0],[101,0],[101,1],[100,1],[100,0]],[[100.2,0.2],[100.8,0.2],[100.8,0.8],[100.2,0.8],[100.2,0.2]]]]}`,
+		s2.LatLngFromDegrees(2.5, 102.5), s2.LatLngFromDegrees(0.5, 100.5)},
+	{`{"type":"GeometryCollection","geometries":[` +
+		`{"type":"Point","coordinates":[100,0]},` +
+		`{"type":"LineString","coordinates":[[101,0],[102,1]]},` +
+		`{"type":"Polygon","coordinates":[[[102,2],[103,2],[103,3],[102,3],[102,2]]]}]}`,
+		s2.LatLngFromDegrees(2.5, 102.5), s2.LatLngFromDegrees(5, 105)},
+}
+
+func TestGeoJSONGeometryMulti(t *testing.T) {
+	for _, tt := range geoJSONMultiTests {
+		var geom latlong.GeoJSONGeometry
+		if err := json.Unmarshal([]byte(tt.jsonstring), &geom); err != nil {
+			t.Errorf("Unmarshal error: %v", err)
+			continue
+		}
+
+		r := geom.S2Region()
+		if r.RectBound().DistanceToLatLng(tt.in).Degrees() > 1e-9 {
+			t.Errorf("%s does not contain %v", geom.Geo().Type(), tt.in)
+		}
+		if r.ContainsPoint(s2.PointFromLatLng(tt.out)) {
+			t.Errorf("%s contains %v", geom.Geo().Type(), tt.out)
+		}
+
+		b, err := json.Marshal(geom)
+		if err != nil {
+			t.Errorf("Marshal error: %v", err)
+			continue
+		}
+		var geom1 latlong.GeoJSONGeometry
+		if err := json.Unmarshal(b, &geom1); err != nil {
+			t.Errorf("Unmarshal error: %v", err)
+			continue
+		}
+		if !geom.Equal(geom1) {
+			t.Errorf("round trip mismatch %s", string(b))
+		}
+	}
+}
+
+func TestGeoJSONGeometryInvalid(t *testing.T) {
+	for _, jsonstring := range []string{
+		`{"type":"LineString","coordinates":[["a"]]}`,
+		`{"type":"MultiLineString","coordinates":[[["a"]]]}`,
+	} {
+		var geom latlong.GeoJSONGeometry
+		if err := json.Unmarshal([]byte(jsonstring), &geom); err == nil {
+			t.Errorf("no error for %s", jsonstring)
+		}
+	}
+}
+
+func TestGeoJSONGeometryEmptyCollection(t *testing.T) {
+	var geom latlong.GeoJSONGeometry
+	if err := json.Unmarshal([]byte(`{"type":"GeometryCollection","geometries":[]}`), &geom); err != nil {
+		t.Fatal(err)
+	}
+	if p := geom.Geo().S2Point(); p != (s2.Point{}) {
+		t.Errorf("was %v", p)
+	}
+
+	p := latlong.NewPoint(latlong.NewAngle(35, 0), latlong.NewAngle(135, 0), nil)
+	if s := (latlong.GeometryCollection{nil, p}).S2Point(); s != p.S2Point() {
+		t.Errorf("was %v", s)
+	}
+	if s := (latlong.GeometryCollection{nil}).S2Point(); s != (s2.Point{}) {
+		t.Errorf("was %v", s)
+	}
+}
@@ -0,0 +1,207 @@
+package latlong
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// GeoJSONFeatureReader reads GeoJSONFeature one by one without loading all features.
+// Input is FeatureCollection, GeoJSON Text Sequences (RFC 8142) or newline-delimited Features.
+type GeoJSONFeatureReader struct {
+	dec          *json.Decoder
+	inCollection bool // in features array of FeatureCollection.
+	inObject     bool // in FeatureCollection after features array.
+}
+
+// NewGeoJSONFeatureReader is constructor.
+func NewGeoJSONFeatureReader(r io.Reader) *GeoJSONFeatureReader {
+	return &GeoJSONFeatureReader{dec: json.NewDecoder(rsReader{r})}
+}
+
+// rsReader replaces record separator (0x1E) of RFC 8142 with space,
+// which never appears in JSON text except as whitespace.
+type rsReader struct {
+	r io.Reader
+}
+
+func (r rsReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	for i := range p[:n] {
+		if p[i] == 0x1e {
+			p[i] = ' '
+		}
+	}
+	return n, err
+}
+
+// Read returns next feature, or io.EOF at the end of input.
+func (r *GeoJSONFeatureReader) Read() (*GeoJSONFeature, error) {
+	for {
+		if r.inCollection {
+			if r.dec.More() {
+				var f GeoJSONFeature
+				if err := r.dec.Decode(&f); err != nil {
+					return nil, err
+				}
+				return &f, nil
+			}
+			if _, err := r.dec.Token(); err != nil { // ']'
+				return nil, err
+			}
+			r.inCollection = false
+			r.inObject = true
+		}
+		if r.inObject {
+			if err := r.skipMembers(); err != nil {
+				return nil, err
+			}
+			r.inObject = false
+		}
+
+		tok, err := r.dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		if d, ok := tok.(json.Delim); !ok || d != '{' {
+			return nil, errors.New("GeoJSON object expected")
+		}
+		f, err := r.readObject()
+		if err != nil || f != nil {
+			return f, err
+		}
+	}
+}
+
+// readObject reads members of object after '{'.
+// It returns nil feature at features array of FeatureCollection.
+func (r *GeoJSONFeatureReader) readObject() (*GeoJSONFeature, error) {
+	members := make(map[string]json.RawMessage)
+	for r.dec.More() {
+		key, err := r.dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		k, _ := key.(string)
+		if k == "features" && string(members["type"]) != `"Feature"` {
+			tok, err := r.dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			if d, ok := tok.(json.Delim); !ok || d != '[' {
+				return nil, errors.New("GeoJSON features must be array")
+			}
+			r.inCollection = true
+			return nil, nil
+		}
+		var v json.RawMessage
+		if err := r.dec.Decode(&v); err != nil {
+			return nil, err
+		}
+		members[k] = v
+	}
+	if _, err := r.dec.Token(); err != nil { // '}'
+		return nil, err
+	}
+
+	var typ string
+	if err := json.Unmarshal(members["type"], &typ); err != nil || typ != "Feature" {
+		return nil, errors.New("GeoJSON Feature expected")
+	}
+	b, err := json.Marshal(members)
+	if err != nil {
+		return nil, err
+	}
+	var f GeoJSONFeature
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// skipMembers skips rest of object and '}'.
+func (r *GeoJSONFeatureReader) skipMembers() error {
+	for r.dec.More() {
+		if _, err := r.dec.Token(); err != nil {
+			return err
+		}
+		var v json.RawMessage
+		if err := r.dec.Decode(&v); err != nil {
+			return err
+		}
+	}
+	_, err := r.dec.Token()
+	return err
+}
+
+// GeoJSONFeatureWriter writes GeoJSONFeature one by one.
+type GeoJSONFeatureWriter struct {
+	w          io.Writer
+	collection bool
+	rs         bool
+	n          int
+	closed     bool
+}
+
+// NewGeoJSONFeatureCollectionWriter writes FeatureCollection. Close must be called at the end.
+func NewGeoJSONFeatureCollectionWriter(w io.Writer) *GeoJSONFeatureWriter {
+	return &GeoJSONFeatureWriter{w: w, collection: true}
+}
+
+// NewGeoJSONSeqWriter writes GeoJSON Text Sequences (RFC 8142), which is application/geo+json-seq.
+func NewGeoJSONSeqWriter(w io.Writer) *GeoJSONFeatureWriter {
+	return &GeoJSONFeatureWriter{w: w, rs: true}
+}
+
+// NewGeoJSONLinesWriter writes newline-delimited Features.
+func NewGeoJSONLinesWriter(w io.Writer) *GeoJSONFeatureWriter {
+	return &GeoJSONFeatureWriter{w: w}
+}
+
+// Write writes f.
+func (fw *GeoJSONFeatureWriter) Write(f *GeoJSONFeature) error {
+	if fw.closed {
+		return errors.New("GeoJSONFeatureWriter is closed")
+	}
+	b, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if fw.collection {
+		if fw.n == 0 {
+			buf.WriteString(`{"type":"FeatureCollection","features":[`)
+		} else {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('\n')
+		buf.Write(b)
+	} else {
+		if fw.rs {
+			buf.WriteByte(0x1e)
+		}
+		buf.Write(b)
+		buf.WriteByte('\n')
+	}
+	if _, err := fw.w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	fw.n++
+	return nil
+}
+
+// Close finishes FeatureCollection. It does not close underlying writer.
+func (fw *GeoJSONFeatureWriter) Close() error {
+	if !fw.collection || fw.closed {
+		fw.closed = true
+		return nil
+	}
+	fw.closed = true
+	s := "\n]}\n"
+	if fw.n == 0 {
+		s = `{"type":"FeatureCollection","features":[]}` + "\n"
+	}
+	_, err := io.WriteString(fw.w, s)
+	return err
+}
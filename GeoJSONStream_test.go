@@ -0,0 +1,113 @@
+package latlong_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	latlong "github.com/toyo/go-latlong"
+)
+
+func readAllFeatures(t *testing.T, s string) (fs []string) {
+	r := latlong.NewGeoJSONFeatureReader(strings.NewReader(s))
+	for {
+		f, err := r.Read()
+		if err == io.EOF {
+			return
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		b, err := json.Marshal(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fs = append(fs, string(b))
+	}
+}
+
+func TestGeoJSONFeatureReader(t *testing.T) {
+	expct := []string{
+		`{"type":"Feature","geometry":{"type":"Point","coordinates":[102.0,0.5]},"properties":{"prop0":"value0"}}`,
+		`{"type":"Feature","geometry":{"type":"LineString","coordinates":[[102.0,0.0],[103.0,1.0]]},"properties":null}`,
+	}
+
+	for _, s := range []string{
+		`{"type":"FeatureCollection","features":[` + expct[0] + `,` + expct[1] + `],"name":"x"}`,
+		`{"features":[` + expct[0] + `],"type":"FeatureCollection"}` + "\n" + `{"type":"FeatureCollection","features":[` + expct[1] + `]}`,
+		expct[0] + "\n" + expct[1] + "\n",
+		"\x1e" + expct[0] + "\n\x1e" + expct[1] + "\n",
+		`{"properties":{"prop0":"value0"},"geometry":{"type":"Point","coordinates":[102.0,0.5]},"type":"Feature"}` + expct[1],
+	} {
+		fs := readAllFeatures(t, s)
+		if len(fs) != len(expct) {
+			t.Errorf("%s: expected %d features, was %d", s, len(expct), len(fs))
+			continue
+		}
+		for i := range fs {
+			if fs[i] != expct[i] {
+				t.Errorf("expected %s, was %s", expct[i], fs[i])
+			}
+		}
+	}
+
+	for _, s := range []string{`[]`, `{"type":"Point","coordinates":[1,2]}`, `{"type":"FeatureCollection","features":{}}`} {
+		if _, err := latlong.NewGeoJSONFeatureReader(strings.NewReader(s)).Read(); err == nil || err == io.EOF {
+			t.Errorf("expected error %s", s)
+		}
+	}
+}
+
+func TestGeoJSONFeatureWriter(t *testing.T) {
+	fs := []*latlong.GeoJSONFeature{
+		latlong.NewPoint(latlong.NewAngle(35.3606, 0.0001), latlong.NewAngle(138.7274, 0.0001), nil).NewGeoJSONFeature(map[string]interface{}{"name": "Fuji"}),
+		latlong.NewPoint(latlong.NewAngle(35.6586, 0.0001), latlong.NewAngle(139.7454, 0.0001), nil).NewGeoJSONFeature(nil),
+	}
+
+	var b bytes.Buffer
+	w := latlong.NewGeoJSONFeatureCollectionWriter(&b)
+	for _, f := range fs {
+		if err := w.Write(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	var fc latlong.GeoJSONFeatureCollection
+	if err := json.Unmarshal(b.Bytes(), &fc); err != nil || fc.Type != "FeatureCollection" || len(fc.Features) != 2 {
+		t.Errorf("was %s %v", b.String(), err)
+	}
+	if err := w.Write(fs[0]); err == nil {
+		t.Error("expected error after Close")
+	}
+
+	b.Reset()
+	w = latlong.NewGeoJSONFeatureCollectionWriter(&b)
+	if err := w.Close(); err != nil || b.String() != `{"type":"FeatureCollection","features":[]}`+"\n" {
+		t.Errorf("was %s %v", b.String(), err)
+	}
+
+	b.Reset()
+	w = latlong.NewGeoJSONSeqWriter(&b)
+	for _, f := range fs {
+		if err := w.Write(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if strings.Count(b.String(), "\x1e") != 2 || len(readAllFeatures(t, b.String())) != 2 {
+		t.Errorf("was %q", b.String())
+	}
+
+	b.Reset()
+	w = latlong.NewGeoJSONLinesWriter(&b)
+	for _, f := range fs {
+		if err := w.Write(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if strings.Count(b.String(), "\n") != 2 || len(readAllFeatures(t, b.String())) != 2 {
+		t.Errorf("was %q", b.String())
+	}
+}
@@ -0,0 +1,828 @@
+package latlong
+
+import (
+	"math"
+
+	"github.com/golang/geo/s1"
+)
+
+// Ellipsoid is an earth ellipsoid for geodesic calculation.
+// Algorithms are from C. F. F. Karney, Algorithms for geodesics,
+// J. Geodesy 87, 43–55 (2013) https://doi.org/10.1007/s00190-012-0578-z
+// and ported from GeographicLib.
+type Ellipsoid struct {
+	a, f float64 // equatorial radius in meters and flattening.
+
+	f1, e2, ep2, n, b, etol2 float64
+	a3x                      [nA3]float64
+	c3x                      [nC3x]float64
+}
+
+const (
+	nA1  = 6
+	nC1  = 6
+	nC1p = 6
+	nA2  = 6
+	nC2  = 6
+	nA3  = 6
+	nC3  = 6
+	nC3x = nC3 * (nC3 - 1) / 2
+
+	geodesicMaxit1 = 20
+	geodesicMaxit2 = geodesicMaxit1 + 53 + 10
+)
+
+var (
+	geodesicTiny = math.Sqrt(math.SmallestNonzeroFloat64 * (1 << 52)) // sqrt of min normal.
+	geodesicTol0 = math.Nextafter(1, 2) - 1
+	geodesicTol1 = 200 * geodesicTol0
+	geodesicTol2 = math.Sqrt(geodesicTol0)
+	geodesicTolb = geodesicTol0 * geodesicTol2
+	geodesicXthr = 1000 * geodesicTol2
+)
+
+// WGS84 is World Geodetic System 1984 ellipsoid.
+var WGS84 = NewEllipsoid(6378137, 1/298.257223563)
+
+// GRS80 is Geodetic Reference System 1980 ellipsoid used by JGD2000 and JGD2011.
+var GRS80 = NewEllipsoid(6378137, 1/298.257222101)
+
+// NewEllipsoid is constructor for Ellipsoid.
+// a is equatorial radius in meters, f is flattening.
+func NewEllipsoid(a, f float64) *Ellipsoid {
+	e := &Ellipsoid{a: a, f: f}
+	e.f1 = 1 - f
+	e.e2 = f * (2 - f)
+	e.ep2 = e.e2 / (e.f1 * e.f1)
+	e.n = f / (2 - f)
+	e.b = a * e.f1
+	e.etol2 = 0.1 * geodesicTol2 / math.Sqrt(math.Max(0.001, math.Abs(f))*math.Min(1, 1-f/2)/2)
+	e.a3coeff()
+	e.c3coeff()
+	return e
+}
+
+// EquatorialRadius returns equatorial radius in meters.
+func (e *Ellipsoid) EquatorialRadius() float64 {
+	return e.a
+}
+
+// Flattening returns flattening.
+func (e *Ellipsoid) Flattening() float64 {
+	return e.f
+}
+
+// GeodesicInverse solves the inverse geodesic problem on ellipsoid e.
+// azi1 is azimuth at latlong and azi2 is forward azimuth at latlong1,
+// so the reverse azimuth (from latlong1 to latlong) is azi2 + 180 degrees.
+// It converges also for nearly antipodal points.
+func (latlong Point) GeodesicInverse(latlong1 *Point, e *Ellipsoid) (km Km, azi1, azi2 s1.Angle) {
+	s12, a1, a2 := e.inverse(latlong.Lat().Degrees(), latlong.Lng().Degrees(), latlong1.Lat().Degrees(), latlong1.Lng().Degrees())
+	return Km(s12 / 1000), s1.Angle(a1) * s1.Degree, s1.Angle(a2) * s1.Degree
+}
+
+// DistanceEllipsoidKm in km on WGS84 ellipsoid.
+func (latlong Point) DistanceEllipsoidKm(latlong1 *Point) Km {
+	km, _, _ := latlong.GeodesicInverse(latlong1, WGS84)
+	return km
+}
+
+// GeodesicDirect solves the direct geodesic problem on ellipsoid e.
+// It returns the point at distance km along azimuth azi1,
+// and the forward azimuth at that point.
+// Precision and datum of the point are inherited from latlong.
+func (latlong Point) GeodesicDirect(azi1 s1.Angle, km Km, e *Ellipsoid) (p Point, azi2 s1.Angle) {
+	lat2, lng2, a2 := e.direct(latlong.Lat().Degrees(), latlong.Lng().Degrees(), azi1.Degrees(), float64(km)*1000)
+	p = NewPoint(
+		NewAngleFromS1Angle(s1.Angle(lat2)*s1.Degree, latlong.lat.radianprec),
+		NewAngleFromS1Angle(s1.Angle(lng2)*s1.Degree, latlong.lng.radianprec),
+		nil)
+	p.datum = latlong.datum
+	return p, s1.Angle(a2) * s1.Degree
+}
+
+// inverse returns distance in meters and azimuths in degrees.
+func (e *Ellipsoid) inverse(lat1, lon1, lat2, lon2 float64) (s12, azi1, azi2 float64) {
+	lon12, lon12s := angDiff(lon1, lon2)
+	lonsign := 1.0
+	if lon12 < 0 {
+		lonsign = -1
+	}
+	lon12 = lonsign * angRound(lon12)
+	lon12s = angRound((180 - lon12) - lonsign*lon12s)
+	lam12 := lon12 * math.Pi / 180
+	var slam12, clam12 float64
+	if lon12 > 90 {
+		slam12, clam12 = sincosd(lon12s)
+		clam12 = -clam12
+	} else {
+		slam12, clam12 = sincosd(lon12)
+	}
+
+	lat1 = angRound(latFix(lat1))
+	lat2 = angRound(latFix(lat2))
+	swapp := 1.0
+	if math.Abs(lat1) < math.Abs(lat2) {
+		swapp = -1
+		lonsign = -lonsign
+		lat1, lat2 = lat2, lat1
+	}
+	latsign := -1.0
+	if lat1 < 0 {
+		latsign = 1
+	}
+	lat1 *= latsign
+	lat2 *= latsign
+
+	sbet1, cbet1 := sincosd(lat1)
+	sbet1 *= e.f1
+	sbet1, cbet1 = norm2(sbet1, cbet1)
+	cbet1 = math.Max(geodesicTiny, cbet1)
+
+	sbet2, cbet2 := sincosd(lat2)
+	sbet2 *= e.f1
+	sbet2, cbet2 = norm2(sbet2, cbet2)
+	cbet2 = math.Max(geodesicTiny, cbet2)
+
+	if cbet1 < -sbet1 {
+		if cbet2 == cbet1 {
+			sbet2 = math.Copysign(sbet1, sbet2)
+		}
+	} else if math.Abs(sbet2) == -sbet1 {
+		cbet2 = cbet1
+	}
+
+	dn1 := math.Sqrt(1 + e.ep2*sbet1*sbet1)
+	dn2 := math.Sqrt(1 + e.ep2*sbet2*sbet2)
+
+	var c1a [nC1 + 1]float64
+	var c2a [nC2 + 1]float64
+	var c3a [nC3]float64
+
+	var salp1, calp1, salp2, calp2, sig12, s12x float64
+
+	meridian := lat1 == -90 || slam12 == 0
+	if meridian {
+		calp1, salp1 = clam12, slam12
+		calp2, salp2 = 1, 0
+		ssig1, csig1 := sbet1, calp1*cbet1
+		ssig2, csig2 := sbet2, calp2*cbet2
+		sig12 = math.Atan2(math.Max(0, csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+		var m12x float64
+		s12x, m12x, _ = e.lengths(e.n, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, true, true, c1a[:], c2a[:])
+		if sig12 < 1 || m12x >= 0 {
+			if sig12 < 3*geodesicTiny || (sig12 < geodesicTol0 && (s12x < 0 || m12x < 0)) {
+				s12x = 0
+			}
+			s12x *= e.b
+		} else {
+			meridian = false
+		}
+	}
+
+	if !meridian && sbet1 == 0 && (e.f <= 0 || lon12s >= e.f*180) {
+		// along the equator.
+		calp1, calp2 = 0, 0
+		salp1, salp2 = 1, 1
+		s12x = e.a * lam12
+	} else if !meridian {
+		var dnm float64
+		sig12, salp1, calp1, salp2, calp2, dnm = e.inverseStart(sbet1, cbet1, dn1, sbet2, cbet2, dn2, lam12, slam12, clam12, c1a[:], c2a[:])
+		if sig12 >= 0 {
+			s12x = sig12 * e.b * dnm
+		} else {
+			var ssig1, csig1, ssig2, csig2, eps float64
+			tripn, tripb := false, false
+			salp1a, calp1a := geodesicTiny, 1.0
+			salp1b, calp1b := geodesicTiny, -1.0
+			for numit := 0; numit < geodesicMaxit2; {
+				var v, dv float64
+				v, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, dv = e.lambda12(sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam12, clam12, numit < geodesicMaxit1, c1a[:], c2a[:], c3a[:])
+				tol := geodesicTol0
+				if tripn {
+					tol *= 8
+				}
+				if tripb || !(math.Abs(v) >= tol) {
+					break
+				}
+				if v > 0 && (numit > geodesicMaxit1 || calp1/salp1 > calp1b/salp1b) {
+					salp1b, calp1b = salp1, calp1
+				} else if v < 0 && (numit > geodesicMaxit1 || calp1/salp1 < calp1a/salp1a) {
+					salp1a, calp1a = salp1, calp1
+				}
+				numit++
+				if numit < geodesicMaxit1 && dv > 0 {
+					dalp1 := -v / dv
+					sdalp1, cdalp1 := math.Sincos(dalp1)
+					nsalp1 := salp1*cdalp1 + calp1*sdalp1
+					if nsalp1 > 0 && math.Abs(dalp1) < math.Pi {
+						calp1 = calp1*cdalp1 - salp1*sdalp1
+						salp1 = nsalp1
+						salp1, calp1 = norm2(salp1, calp1)
+						tripn = math.Abs(v) <= 16*geodesicTol0
+						continue
+					}
+				}
+				// bisection.
+				salp1 = (salp1a + salp1b) / 2
+				calp1 = (calp1a + calp1b) / 2
+				salp1, calp1 = norm2(salp1, calp1)
+				tripn = false
+				tripb = math.Abs(salp1a-salp1)+(calp1a-calp1) < geodesicTolb ||
+					math.Abs(salp1-salp1b)+(calp1-calp1b) < geodesicTolb
+			}
+			s12x, _, _ = e.lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, true, false, c1a[:], c2a[:])
+			s12x *= e.b
+		}
+	}
+
+	s12 = 0 + s12x
+
+	if swapp < 0 {
+		salp1, salp2 = salp2, salp1
+		calp1, calp2 = calp2, calp1
+	}
+	salp1 *= swapp * lonsign
+	calp1 *= swapp * latsign
+	salp2 *= swapp * lonsign
+	calp2 *= swapp * latsign
+
+	azi1 = atan2d(salp1, calp1)
+	azi2 = atan2d(salp2, calp2)
+	return
+}
+
+// direct returns destination and azimuth in degrees from distance s12 in meters.
+func (e *Ellipsoid) direct(lat1, lon1, azi1, s12 float64) (lat2, lon2, azi2 float64) {
+	lat1 = latFix(lat1)
+	salp1, calp1 := sincosd(angRound(azi1))
+	sbet1, cbet1 := sincosd(angRound(lat1))
+	sbet1 *= e.f1
+	sbet1, cbet1 = norm2(sbet1, cbet1)
+	cbet1 = math.Max(geodesicTiny, cbet1)
+
+	salp0 := salp1 * cbet1
+	calp0 := math.Hypot(calp1, salp1*sbet1)
+	ssig1 := sbet1
+	somg1 := salp0 * sbet1
+	csig1 := 1.0
+	if sbet1 != 0 || calp1 != 0 {
+		csig1 = cbet1 * calp1
+	}
+	comg1 := csig1
+	ssig1, csig1 = norm2(ssig1, csig1)
+
+	k2 := calp0 * calp0 * e.ep2
+	eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+
+	var c1a, c1pa [nC1 + 1]float64
+	var c3a [nC3]float64
+	a1m1 := a1m1f(eps)
+	c1f(eps, c1a[:])
+	c1pf(eps, c1pa[:])
+	b11 := sinCosSeries(true, ssig1, csig1, c1a[:])
+	s, c := math.Sincos(b11)
+	stau1 := ssig1*c + csig1*s
+	ctau1 := csig1*c - ssig1*s
+	a3c := -e.f * salp0 * e.a3f(eps)
+	e.c3f(eps, c3a[:])
+	b31 := sinCosSeries(true, ssig1, csig1, c3a[:])
+
+	tau12 := s12 / (e.b * (1 + a1m1))
+	s, c = math.Sincos(tau12)
+	b12 := -sinCosSeries(true, stau1*c+ctau1*s, ctau1*c-stau1*s, c1pa[:])
+	sig12 := tau12 - (b12 - b11)
+	ssig12, csig12 := math.Sincos(sig12)
+	if math.Abs(e.f) > 0.01 {
+		ssig2 := ssig1*csig12 + csig1*ssig12
+		csig2 := csig1*csig12 - ssig1*ssig12
+		b12 = sinCosSeries(true, ssig2, csig2, c1a[:])
+		serr := (1+a1m1)*(sig12+(b12-b11)) - s12/e.b
+		sig12 = sig12 - serr/math.Sqrt(1+k2*ssig2*ssig2)
+		ssig12, csig12 = math.Sincos(sig12)
+	}
+	ssig2 := ssig1*csig12 + csig1*ssig12
+	csig2 := csig1*csig12 - ssig1*ssig12
+
+	sbet2 := calp0 * ssig2
+	cbet2 := math.Hypot(salp0, calp0*csig2)
+	if cbet2 == 0 {
+		cbet2, csig2 = geodesicTiny, geodesicTiny
+	}
+	salp2, calp2 := salp0, calp0*csig2
+
+	somg2, comg2 := salp0*ssig2, csig2
+	omg12 := math.Atan2(somg2*comg1-comg2*somg1, comg2*comg1+somg2*somg1)
+	lam12 := omg12 + a3c*(sig12+(sinCosSeries(true, ssig2, csig2, c3a[:])-b31))
+	lon12 := lam12 * 180 / math.Pi
+
+	lon2 = angNormalize(angNormalize(lon1) + angNormalize(lon12))
+	lat2 = atan2d(sbet2, e.f1*cbet2)
+	azi2 = atan2d(salp2, calp2)
+	return
+}
+
+func (e *Ellipsoid) lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2 float64, distance, reduced bool, c1a, c2a []float64) (s12b, m12b, m0 float64) {
+	a1 := a1m1f(eps)
+	c1f(eps, c1a)
+	var a2, m0x float64
+	if reduced {
+		a2 = a2m1f(eps)
+		c2f(eps, c2a)
+		m0x = a1 - a2
+		a2 = 1 + a2
+	}
+	a1 = 1 + a1
+
+	var j12 float64
+	if distance {
+		b1 := sinCosSeries(true, ssig2, csig2, c1a) - sinCosSeries(true, ssig1, csig1, c1a)
+		s12b = a1 * (sig12 + b1)
+		if reduced {
+			b2 := sinCosSeries(true, ssig2, csig2, c2a) - sinCosSeries(true, ssig1, csig1, c2a)
+			j12 = m0x*sig12 + (a1*b1 - a2*b2)
+		}
+	} else if reduced {
+		for l := 1; l <= nC2; l++ {
+			c2a[l] = a1*c1a[l] - a2*c2a[l]
+		}
+		j12 = m0x*sig12 + (sinCosSeries(true, ssig2, csig2, c2a) - sinCosSeries(true, ssig1, csig1, c2a))
+	}
+	if reduced {
+		m0 = m0x
+		m12b = dn2*(csig1*ssig2) - dn1*(ssig1*csig2) - csig1*csig2*j12
+	}
+	return
+}
+
+func (e *Ellipsoid) inverseStart(sbet1, cbet1, dn1, sbet2, cbet2, dn2, lam12, slam12, clam12 float64, c1a, c2a []float64) (sig12, salp1, calp1, salp2, calp2, dnm float64) {
+	sig12 = -1
+	sbet12 := sbet2*cbet1 - cbet2*sbet1
+	cbet12 := cbet2*cbet1 + sbet2*sbet1
+	sbet12a := sbet2*cbet1 + cbet2*sbet1
+	shortline := cbet12 >= 0 && sbet12 < 0.5 && cbet2*lam12 < 0.5
+
+	var somg12, comg12 float64
+	if shortline {
+		sbetm2 := (sbet1 + sbet2) * (sbet1 + sbet2)
+		sbetm2 /= sbetm2 + (cbet1+cbet2)*(cbet1+cbet2)
+		dnm = math.Sqrt(1 + e.ep2*sbetm2)
+		omg12 := lam12 / (e.f1 * dnm)
+		somg12, comg12 = math.Sincos(omg12)
+	} else {
+		somg12, comg12 = slam12, clam12
+	}
+
+	salp1 = cbet2 * somg12
+	if comg12 >= 0 {
+		calp1 = sbet12 + cbet2*sbet1*somg12*somg12/(1+comg12)
+	} else {
+		calp1 = sbet12a - cbet2*sbet1*somg12*somg12/(1-comg12)
+	}
+
+	ssig12 := math.Hypot(salp1, calp1)
+	csig12 := sbet1*sbet2 + cbet1*cbet2*comg12
+
+	if shortline && ssig12 < e.etol2 {
+		salp2 = cbet1 * somg12
+		if comg12 >= 0 {
+			calp2 = sbet12 - cbet1*sbet2*(somg12*somg12/(1+comg12))
+		} else {
+			calp2 = sbet12 - cbet1*sbet2*(1-comg12)
+		}
+		salp2, calp2 = norm2(salp2, calp2)
+		sig12 = math.Atan2(ssig12, csig12)
+	} else if math.Abs(e.n) > 0.1 || csig12 >= 0 || ssig12 >= 6*math.Abs(e.n)*math.Pi*cbet1*cbet1 {
+		// zeroth order spherical approximation is OK.
+	} else {
+		// nearly antipodal.
+		lam12x := math.Atan2(-slam12, -clam12)
+		var x, y, lamscale, betscale float64
+		if e.f >= 0 {
+			k2 := sbet1 * sbet1 * e.ep2
+			eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+			lamscale = e.f * cbet1 * e.a3f(eps) * math.Pi
+			betscale = lamscale * cbet1
+			x = lam12x / lamscale
+			y = sbet12a / betscale
+		} else {
+			cbet12a := cbet2*cbet1 - sbet2*sbet1
+			bet12a := math.Atan2(sbet12a, cbet12a)
+			_, m12b, m0 := e.lengths(e.n, math.Pi+bet12a, sbet1, -cbet1, dn1, sbet2, cbet2, dn2, false, true, c1a, c2a)
+			x = -1 + m12b/(cbet1*cbet2*m0*math.Pi)
+			if x < -0.01 {
+				betscale = sbet12a / x
+			} else {
+				betscale = -e.f * cbet1 * cbet1 * math.Pi
+			}
+			lamscale = betscale / cbet1
+			y = lam12x / lamscale
+		}
+
+		if y > -geodesicTol1 && x > -1-geodesicXthr {
+			if e.f >= 0 {
+				salp1 = math.Min(1, -x)
+				calp1 = -math.Sqrt(1 - salp1*salp1)
+			} else {
+				lim := -1.0
+				if x <= -geodesicTol1 {
+					lim = -1 + geodesicTol1
+				}
+				calp1 = math.Max(lim, x)
+				salp1 = math.Sqrt(1 - calp1*calp1)
+			}
+		} else {
+			k := astroid(x, y)
+			var omg12a float64
+			if e.f >= 0 {
+				omg12a = lamscale * (-x * k / (1 + k))
+			} else {
+				omg12a = lamscale * (-y * (1 + k) / k)
+			}
+			somg12, comg12 = math.Sincos(omg12a)
+			comg12 = -comg12
+			salp1 = cbet2 * somg12
+			calp1 = sbet12a - cbet2*sbet1*somg12*somg12/(1-comg12)
+		}
+	}
+
+	if salp1 > 0 {
+		salp1, calp1 = norm2(salp1, calp1)
+	} else {
+		salp1, calp1 = 1, 0
+	}
+	return
+}
+
+func (e *Ellipsoid) lambda12(sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam120, clam120 float64, diffp bool, c1a, c2a, c3a []float64) (lam12, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, dlam12 float64) {
+	if sbet1 == 0 && calp1 == 0 {
+		calp1 = -geodesicTiny
+	}
+	salp0 := salp1 * cbet1
+	calp0 := math.Hypot(calp1, salp1*sbet1)
+
+	ssig1 = sbet1
+	somg1 := salp0 * sbet1
+	csig1 = calp1 * cbet1
+	comg1 := csig1
+	ssig1, csig1 = norm2(ssig1, csig1)
+
+	if cbet2 != cbet1 {
+		salp2 = salp0 / cbet2
+	} else {
+		salp2 = salp1
+	}
+	if cbet2 != cbet1 || math.Abs(sbet2) != -sbet1 {
+		var t float64
+		if cbet1 < -sbet1 {
+			t = (cbet2 - cbet1) * (cbet1 + cbet2)
+		} else {
+			t = (sbet1 - sbet2) * (sbet1 + sbet2)
+		}
+		calp2 = math.Sqrt(calp1*cbet1*calp1*cbet1+t) / cbet2
+	} else {
+		calp2 = math.Abs(calp1)
+	}
+
+	ssig2 = sbet2
+	somg2 := salp0 * sbet2
+	csig2 = calp2 * cbet2
+	comg2 := csig2
+	ssig2, csig2 = norm2(ssig2, csig2)
+
+	sig12 = math.Atan2(math.Max(0, csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+	somg12 := math.Max(0, comg1*somg2-somg1*comg2)
+	comg12 := comg1*comg2 + somg1*somg2
+	eta := math.Atan2(somg12*clam120-comg12*slam120, comg12*clam120+somg12*slam120)
+
+	k2 := calp0 * calp0 * e.ep2
+	eps = k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+	e.c3f(eps, c3a)
+	b312 := sinCosSeries(true, ssig2, csig2, c3a) - sinCosSeries(true, ssig1, csig1, c3a)
+	domg12 := -e.f * e.a3f(eps) * salp0 * (sig12 + b312)
+	lam12 = eta + domg12
+
+	if diffp {
+		if calp2 == 0 {
+			dlam12 = -2 * e.f1 * dn1 / sbet1
+		} else {
+			_, dlam12, _ = e.lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2, false, true, c1a, c2a)
+			dlam12 *= e.f1 / (calp2 * cbet2)
+		}
+	} else {
+		dlam12 = math.NaN()
+	}
+	return
+}
+
+func (e *Ellipsoid) a3f(eps float64) float64 {
+	return polyval(nA3-1, e.a3x[:], 0, eps)
+}
+
+func (e *Ellipsoid) c3f(eps float64, c []float64) {
+	mult := 1.0
+	o := 0
+	for l := 1; l < nC3; l++ {
+		m := nC3 - l - 1
+		mult *= eps
+		c[l] = mult * polyval(m, e.c3x[:], o, eps)
+		o += m + 1
+	}
+}
+
+func (e *Ellipsoid) a3coeff() {
+	coeff := []float64{
+		-3, 128,
+		-2, -3, 64,
+		-1, -3, -1, 16,
+		3, -1, -2, 8,
+		1, -1, 2,
+		1, 1,
+	}
+	o, k := 0, 0
+	for j := nA3 - 1; j >= 0; j-- {
+		m := nA3 - j - 1
+		if j < m {
+			m = j
+		}
+		e.a3x[k] = polyval(m, coeff, o, e.n) / coeff[o+m+1]
+		k++
+		o += m + 2
+	}
+}
+
+func (e *Ellipsoid) c3coeff() {
+	coeff := []float64{
+		3, 128,
+		2, 5, 128,
+		-1, 3, 3, 64,
+		-1, 0, 1, 8,
+		-1, 1, 4,
+		5, 256,
+		1, 3, 128,
+		-3, -2, 3, 64,
+		1, -3, 2, 32,
+		7, 512,
+		-10, 9, 384,
+		5, -9, 5, 192,
+		7, 512,
+		-14, 7, 512,
+		21, 2560,
+	}
+	o, k := 0, 0
+	for l := 1; l < nC3; l++ {
+		for j := nC3 - 1; j >= l; j-- {
+			m := nC3 - j - 1
+			if j < m {
+				m = j
+			}
+			e.c3x[k] = polyval(m, coeff, o, e.n) / coeff[o+m+1]
+			k++
+			o += m + 2
+		}
+	}
+}
+
+func a1m1f(eps float64) float64 {
+	coeff := []float64{1, 4, 64, 0, 256}
+	m := nA1 / 2
+	t := polyval(m, coeff, 0, eps*eps) / coeff[m+1]
+	return (t + eps) / (1 - eps)
+}
+
+func c1f(eps float64, c []float64) {
+	coeff := []float64{
+		-1, 6, -16, 32,
+		-9, 64, -128, 2048,
+		9, -16, 768,
+		3, -5, 512,
+		-7, 1280,
+		-7, 2048,
+	}
+	seriesCoeff(nC1, coeff, eps, c)
+}
+
+func c1pf(eps float64, c []float64) {
+	coeff := []float64{
+		205, -432, 768, 1536,
+		4005, -4736, 3840, 12288,
+		-225, 116, 384,
+		-7173, 2695, 7680,
+		3467, 7680,
+		38081, 61440,
+	}
+	seriesCoeff(nC1p, coeff, eps, c)
+}
+
+func a2m1f(eps float64) float64 {
+	coeff := []float64{-11, -28, -192, 0, 256}
+	m := nA2 / 2
+	t := polyval(m, coeff, 0, eps*eps) / coeff[m+1]
+	return (t - eps) / (1 + eps)
+}
+
+func c2f(eps float64, c []float64) {
+	coeff := []float64{
+		1, 2, 16, 32,
+		35, 64, 384, 2048,
+		15, 80, 768,
+		7, 35, 512,
+		63, 1280,
+		77, 2048,
+	}
+	seriesCoeff(nC2, coeff, eps, c)
+}
+
+// seriesCoeff evaluates c[1..n] which are polynomials of eps^2 multiplied by eps^l.
+func seriesCoeff(n int, coeff []float64, eps float64, c []float64) {
+	eps2 := eps * eps
+	d := eps
+	o := 0
+	for l := 1; l <= n; l++ {
+		m := (n - l) / 2
+		c[l] = d * polyval(m, coeff, o, eps2) / coeff[o+m+1]
+		o += m + 2
+		d *= eps
+	}
+}
+
+// sinCosSeries evaluates sum(c[i] * sin(2*i*x)) if sinp, otherwise sum(c[i] * cos((2*i+1)*x)),
+// by Clenshaw summation.
+func sinCosSeries(sinp bool, sinx, cosx float64, c []float64) float64 {
+	k := len(c)
+	n := k
+	if sinp {
+		n--
+	}
+	ar := 2 * (cosx - sinx) * (cosx + sinx)
+	var y0, y1 float64
+	if n&1 != 0 {
+		k--
+		y0 = c[k]
+	}
+	for n /= 2; n > 0; n-- {
+		k--
+		y1 = ar*y0 - y1 + c[k]
+		k--
+		y0 = ar*y1 - y0 + c[k]
+	}
+	if sinp {
+		return 2 * sinx * cosx * y0
+	}
+	return cosx * (y0 - y1)
+}
+
+func astroid(x, y float64) float64 {
+	p := x * x
+	q := y * y
+	r := (p + q - 1) / 6
+	if q == 0 && r <= 0 {
+		return 0
+	}
+	s := p * q / 4
+	r2 := r * r
+	r3 := r * r2
+	disc := s * (s + 2*r3)
+	u := r
+	if disc >= 0 {
+		t3 := s + r3
+		if t3 < 0 {
+			t3 -= math.Sqrt(disc)
+		} else {
+			t3 += math.Sqrt(disc)
+		}
+		t := math.Cbrt(t3)
+		u += t
+		if t != 0 {
+			u += r2 / t
+		}
+	} else {
+		ang := math.Atan2(math.Sqrt(-disc), -(s + r3))
+		u += 2 * r * math.Cos(ang/3)
+	}
+	v := math.Sqrt(u*u + q)
+	var uv float64
+	if u < 0 {
+		uv = q / (v - u)
+	} else {
+		uv = u + v
+	}
+	w := (uv - q) / (2 * v)
+	return uv / (math.Sqrt(uv+w*w) + w)
+}
+
+func polyval(n int, p []float64, s int, x float64) float64 {
+	var y float64
+	if n >= 0 {
+		y = p[s]
+	}
+	for ; n > 0; n-- {
+		s++
+		y = y*x + p[s]
+	}
+	return y
+}
+
+func norm2(x, y float64) (float64, float64) {
+	r := math.Hypot(x, y)
+	return x / r, y / r
+}
+
+// sumErr returns u+v and its round-off error.
+func sumErr(u, v float64) (s, t float64) {
+	s = u + v
+	up := s - v
+	vpp := s - up
+	up -= u
+	vpp -= v
+	t = -(up + vpp)
+	return
+}
+
+func angRound(x float64) float64 {
+	const z = 1.0 / 16
+	y := math.Abs(x)
+	if y < z {
+		y = z - (z - y)
+	}
+	return math.Copysign(y, x)
+}
+
+func angNormalize(x float64) float64 {
+	y := math.Remainder(x, 360)
+	if math.Abs(y) == 180 {
+		return math.Copysign(180, x)
+	}
+	return y
+}
+
+func angDiff(x, y float64) (d, t float64) {
+	d, t = sumErr(math.Remainder(-x, 360), math.Remainder(y, 360))
+	d, t1 := sumErr(math.Remainder(d, 360), t)
+	t = t1
+	if d == 0 || math.Abs(d) == 180 {
+		if t == 0 {
+			d = math.Copysign(d, y-x)
+		} else {
+			d = math.Copysign(d, -t)
+		}
+	}
+	return
+}
+
+func latFix(x float64) float64 {
+	if math.Abs(x) > 90 {
+		return math.NaN()
+	}
+	return x
+}
+
+// sincosd is sin and cos of degrees with exact results for multiples of 90.
+func sincosd(x float64) (s, c float64) {
+	r := math.Mod(x, 360)
+	q := 0
+	if !math.IsNaN(r) {
+		q = int(math.Round(r / 90))
+	}
+	r -= 90 * float64(q)
+	s, c = math.Sincos(r * math.Pi / 180)
+	switch ((q % 4) + 4) % 4 {
+	case 1:
+		s, c = c, -s
+	case 2:
+		s, c = -s, -c
+	case 3:
+		s, c = -c, s
+	}
+	c += 0
+	if x == 0 {
+		s = x
+	}
+	return
+}
+
+func atan2d(y, x float64) float64 {
+	q := 0
+	if math.Abs(y) > math.Abs(x) {
+		q = 2
+		x, y = y, x
+	}
+	if x < 0 {
+		q++
+		x = -x
+	}
+	ang := math.Atan2(y, x) * 180 / math.Pi
+	switch q {
+	case 1:
+		if y >= 0 {
+			ang = 180 - ang
+		} else {
+			ang = -180 - ang
+		}
+	case 2:
+		ang = 90 - ang
+	case 3:
+		ang = -90 + ang
+	}
+	return ang
+}
@@ -0,0 +1,74 @@
+package latlong_test
+
+import (
+	"math"
+	"testing"
+
+	latlong "github.com/toyo/go-latlong"
+)
+
+func dms(d, m, s float64) float64 {
+	return math.Copysign(math.Abs(d)+m/60+s/3600, d)
+}
+
+func TestGeodesicInverse(t *testing.T) {
+	tests := []struct {
+		lat1, lng1, lat2, lng2 float64
+		e                      *latlong.Ellipsoid
+		km, azi1, azi2         float64
+	}{
+		// C. F. F. Karney, GeographicLib example, Wellington to Salamanca.
+		{-41.32, 174.81, 40.96, -5.50, latlong.WGS84, 19959.67926735382, 161.06766998615873, 18.825195123248392},
+		// T. Vincenty (1975), Flinders Peak to Buninyong.
+		{dms(-37, 57, 3.72030), dms(144, 25, 29.52440), dms(-37, 39, 10.15610), dms(143, 55, 35.38390), latlong.GRS80,
+			54.972271, dms(306, 52, 5.37) - 360, dms(127, 10, 25.07) - 180},
+	}
+
+	for _, tt := range tests {
+		p1 := latlong.NewPoint(latlong.NewAngle(tt.lat1, 0), latlong.NewAngle(tt.lng1, 0), nil)
+		p2 := latlong.NewPoint(latlong.NewAngle(tt.lat2, 0), latlong.NewAngle(tt.lng2, 0), nil)
+		km, azi1, azi2 := p1.GeodesicInverse(&p2, tt.e)
+		if math.Abs(float64(km)-tt.km) > 1e-6 {
+			t.Errorf("distance expected %v, was %v", tt.km, float64(km))
+		}
+		if math.Abs(azi1.Degrees()-tt.azi1) > 1e-5 || math.Abs(azi2.Degrees()-tt.azi2) > 1e-5 {
+			t.Errorf("azimuth expected %v %v, was %v %v", tt.azi1, tt.azi2, azi1.Degrees(), azi2.Degrees())
+		}
+
+		p3, azi3 := p1.GeodesicDirect(azi1, km, tt.e)
+		if d := p3.DistanceEarthKm(&p2); d > 1e-9 {
+			t.Errorf("direct expected %v, was %v", p2, p3)
+		}
+		if math.Abs(azi3.Degrees()-azi2.Degrees()) > 1e-9 {
+			t.Errorf("direct azimuth expected %v, was %v", azi2.Degrees(), azi3.Degrees())
+		}
+	}
+}
+
+func TestGeodesicAntipodal(t *testing.T) {
+	p1 := latlong.NewPoint(latlong.NewAngle(0, 0), latlong.NewAngle(0, 0), nil)
+	for _, lat2 := range []float64{0, 0.001, 0.5, -0.5, 1} {
+		for _, lng2 := range []float64{179.0, 179.5, 179.9, 179.999, 180} {
+			p2 := latlong.NewPoint(latlong.NewAngle(lat2, 0), latlong.NewAngle(lng2, 0), nil)
+			km, azi1, _ := p1.GeodesicInverse(&p2, latlong.WGS84)
+			if math.IsNaN(float64(km)) || km < 19800 || km > 20010 {
+				t.Errorf("%v,%v: distance %v", lat2, lng2, km)
+				continue
+			}
+			p3, _ := p1.GeodesicDirect(azi1, km, latlong.WGS84)
+			if d := p3.DistanceEarthKm(&p2); d > 1e-6 {
+				t.Errorf("%v,%v: direct %v", lat2, lng2, p3)
+			}
+		}
+	}
+}
+
+func TestDistanceEllipsoidKm(t *testing.T) {
+	tokyo := latlong.NewPoint(latlong.NewAngle(35.681236, 0), latlong.NewAngle(139.767125, 0), nil)
+	osaka := latlong.NewPoint(latlong.NewAngle(34.702485, 0), latlong.NewAngle(135.495951, 0), nil)
+
+	ell, sph := tokyo.DistanceEllipsoidKm(&osaka), tokyo.DistanceEarthKm(&osaka)
+	if math.Abs(float64(ell-sph)) > float64(sph)*0.005 || ell == sph {
+		t.Errorf("ellipsoid %v sphere %v", ell, sph)
+	}
+}
@@ -0,0 +1,143 @@
+package latlong
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/golang/geo/s2"
+)
+
+// GeometryCollection is slice of Geometry
+type GeometryCollection []Geometry
+
+// Type returns this type
+func (GeometryCollection) Type() string {
+	return "GeometryCollection"
+}
+
+// S2Region is getter for s2.Region.
+func (gc GeometryCollection) S2Region() s2.Region {
+	var ru s2.RegionUnion
+	for _, g := range gc {
+		if g == nil {
+			continue
+		}
+		if r := g.S2Region(); r != nil {
+			ru = append(ru, r)
+		}
+	}
+	return ru
+}
+
+// CapBound is for s2.Region interface.
+func (gc *GeometryCollection) CapBound() s2.Cap {
+	return gc.S2Region().CapBound()
+}
+
+// RectBound is for s2.Region interface.
+func (gc *GeometryCollection) RectBound() s2.Rect {
+	return gc.S2Region().RectBound()
+}
+
+// ContainsCell is for s2.Region interface.
+func (gc *GeometryCollection) ContainsCell(c s2.Cell) bool {
+	return gc.S2Region().ContainsCell(c)
+}
+
+// IntersectsCell is for s2.Region interface.
+func (gc *GeometryCollection) IntersectsCell(c s2.Cell) bool {
+	return gc.S2Region().IntersectsCell(c)
+}
+
+// ContainsPoint is for s2.Region interface.
+func (gc *GeometryCollection) ContainsPoint(p s2.Point) bool {
+	return gc.S2Region().ContainsPoint(p)
+}
+
+// CellUnionBound is for s2.Region interface.
+func (gc *GeometryCollection) CellUnionBound() []s2.CellID {
+	return gc.S2Region().CellUnionBound()
+}
+
+// S2Point is Center of the first non-nil geometry, or zero s2.Point if there is none.
+func (gc GeometryCollection) S2Point() s2.Point {
+	for _, g := range gc {
+		if g != nil {
+			return g.S2Point()
+		}
+	}
+	return s2.Point{}
+}
+
+// Radiusp is un-used
+func (gc GeometryCollection) Radiusp() *float64 {
+	return nil
+}
+
+func (gc GeometryCollection) String() string {
+	var ss []string
+	for _, g := range gc {
+		if g == nil {
+			ss = append(ss, "Null")
+		} else {
+			ss = append(ss, g.String())
+		}
+	}
+	return strings.Join(ss, "/")
+}
+
+// Equal return bool
+func (gc GeometryCollection) Equal(c1 Geometry) bool {
+	c, ok := c1.(GeometryCollection)
+	if !ok || len(gc) != len(c) {
+		return false
+	}
+	for i := range gc {
+		if gc[i] == nil || c[i] == nil {
+			if gc[i] != c[i] {
+				return false
+			}
+		} else if gc[i].Type() != c[i].Type() || !gc[i].Equal(c[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalJSON is a marshaler for JSON. It is the array of "geometries".
+func (gc GeometryCollection) MarshalJSON() ([]byte, error) {
+	geoms := make([]GeoJSONGeometry, len(gc))
+	for i := range gc {
+		geoms[i].geo = gc[i]
+	}
+	return json.Marshal(geoms)
+}
+
+// UnmarshalJSON is a unmarshaler for JSON from the array of "geometries".
+func (gc *GeometryCollection) UnmarshalJSON(data []byte) error {
+	var geoms []GeoJSONGeometry
+	if err := json.Unmarshal(data, &geoms); err != nil {
+		return err
+	}
+	*gc = make(GeometryCollection, len(geoms))
+	for i := range geoms {
+		(*gc)[i] = geoms[i].geo
+	}
+	return nil
+}
+
+// NewGeoJSONGeometry returns GeoJSONGeometry.
+func (gc GeometryCollection) NewGeoJSONGeometry() *GeoJSONGeometry {
+	var g GeoJSONGeometry
+	g.geo = gc
+	return &g
+}
+
+// NewGeoJSONFeature returns GeoJSONFeature.
+func (gc GeometryCollection) NewGeoJSONFeature(property interface{}) *GeoJSONFeature {
+	var g GeoJSONFeature
+	g.Type = "Feature"
+	g.Geometry = gc.NewGeoJSONGeometry()
+	g.Property = property
+	return &g
+}
@@ -0,0 +1,274 @@
+package latlong
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/golang/geo/s1"
+)
+
+// Reasons of ISO6709Error.
+var (
+	ErrISO6709Sign      = errors.New("sign is required")
+	ErrISO6709Digits    = errors.New("invalid number of digits")
+	ErrISO6709Fraction  = errors.New("fraction has no digits")
+	ErrISO6709Minute    = errors.New("minute or second out of range")
+	ErrISO6709Latitude  = errors.New("latitude out of range")
+	ErrISO6709Longitude = errors.New("longitude out of range")
+	ErrISO6709CRS       = errors.New("empty CRS identifier")
+	ErrISO6709Trailing  = errors.New("unexpected trailing characters")
+)
+
+// ISO6709Error is an error with position on parsing ISO6709 string.
+type ISO6709Error struct {
+	Text   string // input
+	Offset int    // byte offset in Text
+	Reason error  // one of ErrISO6709*
+}
+
+func (e *ISO6709Error) Error() string {
+	return fmt.Sprintf("ISO6709 %q at offset %d: %v", e.Text, e.Offset, e.Reason)
+}
+
+// Unwrap returns Reason.
+func (e *ISO6709Error) Unwrap() error {
+	return e.Reason
+}
+
+// ParseISO6709 parses one point of ISO6709:2008 Annex H,
+// such as "+35.6+139.7-10000CRSWGS_84/".
+func ParseISO6709(iso6709 []byte) (latlong Point, err error) {
+	latlong, _, err = parseISO6709(iso6709)
+	return
+}
+
+// parseISO6709 returns point and CRS identifier (empty if none).
+func parseISO6709(b []byte) (latlong Point, crs string, err error) {
+	seterr := func(offset int, reason error) {
+		err = &ISO6709Error{Text: string(b), Offset: offset, Reason: reason}
+	}
+
+	lat, i, offset, reason := parseISO6709Angle(b, 0, 2)
+	if reason != nil {
+		seterr(offset, reason)
+		return
+	}
+	if math.Abs(lat.Degrees()) > 90 {
+		seterr(0, ErrISO6709Latitude)
+		return
+	}
+
+	start := i
+	lng, i, offset, reason := parseISO6709Angle(b, i, 3)
+	if reason != nil {
+		seterr(offset, reason)
+		return
+	}
+	if math.Abs(lng.Degrees()) > 180 {
+		seterr(start, ErrISO6709Longitude)
+		return
+	}
+
+	var altitude *float64
+	if i < len(b) && (b[i] == '+' || b[i] == '-') {
+		start = i
+		j := skipDigits(b, i+1)
+		if j == i+1 {
+			seterr(j, ErrISO6709Digits)
+			return
+		}
+		if j < len(b) && b[j] == '.' {
+			k := skipDigits(b, j+1)
+			if k == j+1 {
+				seterr(k, ErrISO6709Fraction)
+				return
+			}
+			j = k
+		}
+		altitude = getAlt(b[start:j])
+		i = j
+	}
+
+	if bytes.HasPrefix(b[i:], []byte(`CRS`)) {
+		start = i
+		j := i + len(`CRS`)
+		for j < len(b) && b[j] != '/' {
+			j++
+		}
+		if j == i+len(`CRS`) {
+			seterr(start, ErrISO6709CRS)
+			return
+		}
+		crs = string(b[i+len(`CRS`) : j])
+		i = j
+	}
+
+	if i < len(b) && b[i] == '/' {
+		i++
+	}
+	if i != len(b) {
+		seterr(i, ErrISO6709Trailing)
+		return
+	}
+
+	latlong = NewPoint(lat, lng, altitude)
+	latlong.datum = datumFromCRS(crs)
+	return
+}
+
+// parseISO6709Angle parses ±DD[MM[SS]][.f] from b[i:].
+// degdigits is 2 for latitude and 3 for longitude.
+// Precision is the unit of the last digit same as AngleFromBytes.
+func parseISO6709Angle(b []byte, i, degdigits int) (a Angle, next int, offset int, reason error) {
+	if i >= len(b) || (b[i] != '+' && b[i] != '-') {
+		return a, i, i, ErrISO6709Sign
+	}
+	neg := b[i] == '-'
+
+	intstart := i + 1
+	intend := skipDigits(b, intstart)
+	next = intend
+
+	fracdigits := 0
+	if next < len(b) && b[next] == '.' {
+		fracend := skipDigits(b, next+1)
+		if fracend == next+1 {
+			return a, next, fracend, ErrISO6709Fraction
+		}
+		fracdigits = fracend - next - 1
+		next = fracend
+	}
+
+	var unit float64
+	switch intend - intstart {
+	case degdigits:
+		unit = 1
+	case degdigits + 2:
+		unit = 60
+	case degdigits + 4:
+		unit = 3600
+	default:
+		return a, next, intstart, ErrISO6709Digits
+	}
+
+	var deg float64
+	var err error
+	if deg, err = strconv.ParseFloat(string(b[intstart:intstart+degdigits]), 64); err != nil {
+		return a, next, intstart, ErrISO6709Digits
+	}
+	if unit == 1 {
+		// fraction of degree.
+		if deg, err = strconv.ParseFloat(string(b[intstart:next]), 64); err != nil {
+			return a, next, intstart, ErrISO6709Digits
+		}
+	} else {
+		minend := next
+		if unit == 3600 {
+			minend = intstart + degdigits + 2
+		}
+		var min float64
+		if min, err = strconv.ParseFloat(string(b[intstart+degdigits:minend]), 64); err != nil || min >= 60 {
+			return a, next, intstart + degdigits, ErrISO6709Minute
+		}
+		deg += min / 60
+
+		if unit == 3600 {
+			var sec float64
+			if sec, err = strconv.ParseFloat(string(b[minend:next]), 64); err != nil || sec >= 60 {
+				return a, next, minend, ErrISO6709Minute
+			}
+			deg += sec / 3600
+		}
+	}
+	if neg {
+		deg = -deg
+	}
+
+	degprec := math.Pow10(-fracdigits) / unit
+	a = Angle{radian: s1.Angle(deg) * s1.Degree, radianprec: s1.Angle(degprec) * s1.Degree}
+	return
+}
+
+func skipDigits(b []byte, i int) int {
+	for i < len(b) && '0' <= b[i] && b[i] <= '9' {
+		i++
+	}
+	return i
+}
+
+// appendISO6709Angle appends ±DD[MM[SS]][.f] to b.
+// Form and number of digits are chosen from precision of a.
+func appendISO6709Angle(b []byte, a Angle, degdigits int) []byte {
+	const maxfracdigits = 10
+
+	unit, fracdigits := float64(1), a.preclog()
+	if prec := a.PrecDegrees(); prec > 0 {
+		for _, u := range []float64{1, 60, 3600} {
+			x := -math.Log10(prec * u)
+			if k := math.Round(x); k >= 0 && math.Abs(x-k) < 1e-6 {
+				unit, fracdigits = u, int(k)
+				break
+			}
+		}
+	}
+	if fracdigits > maxfracdigits {
+		fracdigits = maxfracdigits
+	}
+
+	deg := a.Degrees()
+	if deg < 0 {
+		b = append(b, '-')
+	} else {
+		b = append(b, '+')
+	}
+
+	scale := int64(math.Pow10(fracdigits))
+	total := int64(math.Round(math.Abs(deg) * unit * float64(scale)))
+	frac, whole := total%scale, total/scale
+
+	switch unit {
+	case 1:
+		b = appendDigits(b, whole, degdigits)
+	case 60:
+		b = appendDigits(b, whole/60, degdigits)
+		b = appendDigits(b, whole%60, 2)
+	case 3600:
+		b = appendDigits(b, whole/3600, degdigits)
+		b = appendDigits(b, whole/60%60, 2)
+		b = appendDigits(b, whole%60, 2)
+	}
+	if fracdigits > 0 {
+		b = append(b, '.')
+		b = appendDigits(b, frac, fracdigits)
+	}
+	return b
+}
+
+// appendDigits appends n with leading zeros to width.
+func appendDigits(b []byte, n int64, width int) []byte {
+	s := strconv.FormatInt(n, 10)
+	for i := len(s); i < width; i++ {
+		b = append(b, '0')
+	}
+	return append(b, s...)
+}
+
+// appendISO6709 appends latlong with "/" terminator to b.
+func appendISO6709(b []byte, latlong Point) []byte {
+	b = appendISO6709Angle(b, latlong.lat, 2)
+	b = appendISO6709Angle(b, latlong.lng, 3)
+	if latlong.alt != nil {
+		if *latlong.alt >= 0 {
+			b = append(b, '+')
+		}
+		b = strconv.AppendFloat(b, *latlong.alt, 'f', -1, 64)
+	}
+	if crs := latlong.datum.crs(); crs != "" {
+		b = append(b, `CRS`...)
+		b = append(b, crs...)
+	}
+	return append(b, '/')
+}
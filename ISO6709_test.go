@@ -0,0 +1,125 @@
+package latlong_test
+
+import (
+	"errors"
+	"testing"
+
+	latlong "github.com/toyo/go-latlong"
+)
+
+func TestParseISO6709(t *testing.T) {
+	alt := float64(-10000)
+	tests := []struct {
+		in    string
+		expct latlong.Point
+	}{
+		{`+35.6+139.7-10000/`, latlong.NewPoint(latlong.NewAngle(35.6, 0.1), latlong.NewAngle(139.7, 0.1), &alt)},
+		{`+35.6+139.7-10000CRSWGS_84/`, latlong.NewPoint(latlong.NewAngle(35.6, 0.1), latlong.NewAngle(139.7, 0.1), &alt)},
+		{`+35+139`, latlong.NewPoint(latlong.NewAngle(35, 1), latlong.NewAngle(139, 1), nil)},
+		{`-90+180/`, latlong.NewPoint(latlong.NewAngle(-90, 1), latlong.NewAngle(180, 1), nil)},
+	}
+
+	for _, tt := range tests {
+		p, err := latlong.ParseISO6709([]byte(tt.in))
+		if err != nil {
+			t.Errorf("%s: %v", tt.in, err)
+			continue
+		}
+		if p.Lat() != tt.expct.Lat() || p.Lng() != tt.expct.Lng() {
+			t.Errorf("%s: expected %#v, was %#v", tt.in, tt.expct, p)
+		}
+		if (p.Alt() == nil) != (tt.expct.Alt() == nil) || (p.Alt() != nil && *p.Alt() != *tt.expct.Alt()) {
+			t.Errorf("%s: altitude expected %v, was %v", tt.in, tt.expct.Alt(), p.Alt())
+		}
+	}
+}
+
+func TestParseISO6709Error(t *testing.T) {
+	tests := []struct {
+		in     string
+		offset int
+		reason error
+	}{
+		{`35.6+139.7/`, 0, latlong.ErrISO6709Sign},
+		{`+35.6139.7/`, 8, latlong.ErrISO6709Sign},
+		{`+3+139/`, 1, latlong.ErrISO6709Digits},
+		{`+35.+139/`, 4, latlong.ErrISO6709Fraction},
+		{`+3560+13900/`, 3, latlong.ErrISO6709Minute},
+		{`+91+139/`, 0, latlong.ErrISO6709Latitude},
+		{`+35+181/`, 3, latlong.ErrISO6709Longitude},
+		{`+35+139CRS/`, 7, latlong.ErrISO6709CRS},
+		{`+35+139/x`, 8, latlong.ErrISO6709Trailing},
+	}
+
+	for _, tt := range tests {
+		_, err := latlong.ParseISO6709([]byte(tt.in))
+		var e *latlong.ISO6709Error
+		if !errors.As(err, &e) {
+			t.Errorf("%s: expected *ISO6709Error, was %v", tt.in, err)
+			continue
+		}
+		if e.Offset != tt.offset || !errors.Is(err, tt.reason) {
+			t.Errorf("%s: expected %d %v, was %d %v", tt.in, tt.offset, tt.reason, e.Offset, e.Reason)
+		}
+	}
+}
+
+func TestMultiPointUnmarshalTextError(t *testing.T) {
+	var mp latlong.MultiPoint
+	err := mp.UnmarshalText([]byte(`+35+139/+95+139/`))
+
+	var e *latlong.ISO6709Error
+	if !errors.As(err, &e) {
+		t.Fatalf("expected *ISO6709Error, was %v", err)
+	}
+	if e.Offset != 8 || e.Reason != latlong.ErrISO6709Latitude {
+		t.Errorf("expected offset 8, was %d %v", e.Offset, e.Reason)
+	}
+}
+
+func TestISO6709MarshalText(t *testing.T) {
+	for _, s := range []string{
+		`+35.6+139.7-10000/`,
+		`+12.34+123.45+3776/+0123.4-01234.5-3776/-001234+0012345-12345/`,
+		`+352139+1384339+3776/`,
+		`-0030.25+00000.5+0/`,
+	} {
+		var ls latlong.LineString
+		if err := ls.UnmarshalText([]byte(s)); err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+		b, err := ls.MarshalText()
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+		if string(b) != s {
+			t.Errorf("expected %s, was %s", s, string(b))
+		}
+
+		var ls1 latlong.LineString
+		if err := ls1.UnmarshalText(b); err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+		for i := range ls.MultiPoint {
+			p, p1 := ls.MultiPoint[i], ls1.MultiPoint[i]
+			if p.Lat() != p1.Lat() || p.Lng() != p1.Lng() || *p.Alt() != *p1.Alt() {
+				t.Errorf("%s: round trip mismatch %#v %#v", s, p, p1)
+			}
+		}
+	}
+}
+
+func TestPointMarshalText(t *testing.T) {
+	p := latlong.NewPoint(latlong.NewAngle(35.6895, 0.0001), latlong.NewAngle(-139.6917, 0.0001), nil)
+	b, err := p.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expct := `+35.6895-139.6917/`
+	if string(b) != expct {
+		t.Errorf("expected %s, was %s", expct, string(b))
+	}
+}
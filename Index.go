@@ -0,0 +1,385 @@
+package latlong
+
+import (
+	"sort"
+
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+)
+
+// Index is spatial index of Geometry with payload, backed by s2.ShapeIndex.
+// Polygons are converted to s2.Polygon once when they are added.
+type Index struct {
+	index     *s2.ShapeIndex
+	shapes    map[s2.Shape]*IndexEntry
+	entries   map[*IndexEntry]struct{}
+	maxRadius s1.Angle // maximum radius of circles.
+	seq       int
+	stale     bool // s2.ShapeIndex is rebuilt at next query.
+}
+
+// IndexEntry is Geometry or Rect, and payload in Index.
+type IndexEntry struct {
+	Geometry Geometry
+	Rect     *Rect // added by AddRect, and Geometry is nil.
+	Payload  interface{}
+	shapes   []s2.Shape
+	caps     map[s2.Shape]s2.Cap // circles indexed by center.
+	seq      int
+}
+
+// NewIndex is constructor for Index.
+func NewIndex() *Index {
+	return &Index{
+		index:   s2.NewShapeIndex(),
+		shapes:  make(map[s2.Shape]*IndexEntry),
+		entries: make(map[*IndexEntry]struct{}),
+	}
+}
+
+// Len returns number of entries.
+func (idx *Index) Len() int {
+	return len(idx.entries)
+}
+
+// Add adds g with payload, and returns the entry to Remove.
+// Circle is indexed by its center.
+func (idx *Index) Add(g Geometry, payload interface{}) *IndexEntry {
+	e := &IndexEntry{Geometry: g, Payload: payload, seq: idx.seq}
+	idx.seq++
+	for _, part := range geoParts(g) {
+		var shape s2.Shape
+		switch part := part.(type) {
+		case s2.Point:
+			shape = &s2.PointVector{part}
+		case s2.Polyline:
+			shape = &part
+		case *s2.Polygon:
+			shape = part
+		case s2.Cap:
+			shape = &s2.PointVector{part.Center()}
+			if e.caps == nil {
+				e.caps = make(map[s2.Shape]s2.Cap)
+			}
+			e.caps[shape] = part
+		}
+		e.shapes = append(e.shapes, shape)
+		idx.shapes[shape] = e
+	}
+	idx.entries[e] = struct{}{}
+	idx.stale = true
+	return e
+}
+
+// AddRect adds rect with payload, and returns the entry to Remove.
+// Rect is indexed by center of its bounding cap, and edges of latitude are not great circles.
+func (idx *Index) AddRect(rect *Rect, payload interface{}) *IndexEntry {
+	e := &IndexEntry{Rect: rect, Payload: payload, seq: idx.seq}
+	idx.seq++
+	if !rect.IsEmpty() {
+		c := rect.CapBound()
+		shape := &s2.PointVector{c.Center()}
+		e.caps = map[s2.Shape]s2.Cap{shape: c}
+		e.shapes = append(e.shapes, shape)
+		idx.shapes[shape] = e
+	}
+	idx.entries[e] = struct{}{}
+	idx.stale = true
+	return e
+}
+
+// closest returns the closest point of e to p of shape, which is p if e contains p.
+// shape must be a cap of e.
+func (e *IndexEntry) closest(p s2.Point, shape s2.Shape) s2.Point {
+	if e.Rect == nil {
+		c := e.caps[shape]
+		if d := p.Distance(c.Center()); d > c.Radius() {
+			return s2.InterpolateAtDistance(d-c.Radius(), p, c.Center())
+		}
+		return p
+	}
+	r := e.Rect.Rect
+	ll := s2.LatLngFromPoint(p)
+	if r.Lng.Contains(float64(ll.Lng)) {
+		ll.Lat = s1.Angle(r.Lat.ClampPoint(float64(ll.Lat)))
+		return s2.PointFromLatLng(ll)
+	}
+	// the nearer edge of longitude, as s2.Rect.DistanceToLatLng.
+	lng := r.Lng.Lo
+	if s1.IntervalFromEndpoints(r.Lng.Hi, r.Lng.ComplementCenter()).Contains(float64(ll.Lng)) {
+		lng = r.Lng.Hi
+	}
+	return s2.Project(p, s2.PointFromLatLng(s2.LatLng{Lat: s1.Angle(r.Lat.Lo), Lng: s1.Angle(lng)}),
+		s2.PointFromLatLng(s2.LatLng{Lat: s1.Angle(r.Lat.Hi), Lng: s1.Angle(lng)}))
+}
+
+// distance returns the shortest distance from p to e, and 0 if p is in e.
+func (e *IndexEntry) distance(p s2.Point) s1.Angle {
+	if e.Rect != nil {
+		if e.Rect.IsEmpty() {
+			return s1.InfAngle()
+		}
+		return e.Rect.DistanceToLatLng(s2.LatLngFromPoint(p))
+	}
+	return geometryDistance(p, e.Geometry)
+}
+
+// intersects reports whether e intersects g.
+// Edges of Rect are approximated by great circles as Rect.IntersectsRegion except for Point.
+func (e *IndexEntry) intersects(g Geometry) bool {
+	if e.Rect == nil {
+		return Intersects(e.Geometry, g)
+	}
+	for _, part := range geoParts(g) {
+		var region s2.Region
+		switch part := part.(type) {
+		case s2.Point:
+			region = part
+		case s2.Polyline:
+			region = &part
+		case *s2.Polygon:
+			region = part
+		case s2.Cap:
+			region = part
+		}
+		if e.Rect.IntersectsRegion(region) {
+			return true
+		}
+	}
+	return false
+}
+
+// Remove removes e from idx.
+func (idx *Index) Remove(e *IndexEntry) {
+	if _, ok := idx.entries[e]; !ok {
+		return
+	}
+	for _, shape := range e.shapes {
+		delete(idx.shapes, shape)
+	}
+	delete(idx.entries, e)
+	idx.stale = true
+}
+
+// rebuild rebuilds s2.ShapeIndex after Add or Remove.
+// Updates of s2.ShapeIndex after the first query are not reliable in golang/geo,
+// which deadlocks on Add and confuses IDs of shapes on Remove.
+func (idx *Index) rebuild() {
+	if !idx.stale {
+		return
+	}
+	idx.index = s2.NewShapeIndex()
+	idx.maxRadius = 0
+	for _, e := range sortEntries(idx.entries) {
+		for _, shape := range e.shapes {
+			idx.index.Add(shape)
+		}
+		for _, c := range e.caps {
+			if c.Radius() > idx.maxRadius {
+				idx.maxRadius = c.Radius()
+			}
+		}
+	}
+	idx.stale = false
+}
+
+// sortEntries returns entries in order of Add.
+func sortEntries(m map[*IndexEntry]struct{}) []*IndexEntry {
+	es := make([]*IndexEntry, 0, len(m))
+	for e := range m {
+		es = append(es, e)
+	}
+	sort.Slice(es, func(i, j int) bool {
+		return es[i].seq < es[j].seq
+	})
+	return es
+}
+
+// findEntries adds entries which edges or interiors are within limit from target to m.
+func (idx *Index) findEntries(target interface{}, limit s1.Angle, m map[*IndexEntry]struct{}) {
+	opts := s2.NewClosestEdgeQueryOptions().IncludeInteriors(true).
+		DistanceLimit(s1.ChordAngleFromAngle(limit + idx.maxRadius).Successor())
+	q := s2.NewClosestEdgeQuery(idx.index, opts)
+	var rs []s2.EdgeQueryResult
+	switch t := target.(type) {
+	case s2.Point:
+		rs = q.FindEdges(s2.NewMinDistanceToPointTarget(t))
+	case s2.Cell:
+		rs = q.FindEdges(s2.NewMinDistanceToCellTarget(t))
+	}
+	for _, r := range rs {
+		if e, ok := idx.shapes[idx.index.Shape(r.ShapeID())]; ok {
+			m[e] = struct{}{}
+		}
+	}
+}
+
+// ContainingPoint returns entries of Polygon, Rect and Circle which contain latlong.
+// Rect contains latlong as s2.Rect, which edges of latitude are not great circles.
+func (idx *Index) ContainingPoint(latlong Point) []*IndexEntry {
+	idx.rebuild()
+	p := latlong.S2Point()
+	m := make(map[*IndexEntry]struct{})
+	q := s2.NewContainsPointQuery(idx.index, s2.VertexModelSemiOpen)
+	for _, shape := range q.ContainingShapes(p) {
+		if e, ok := idx.shapes[shape]; ok {
+			m[e] = struct{}{}
+		}
+	}
+	if idx.maxRadius > 0 {
+		cm := make(map[*IndexEntry]struct{})
+		idx.findEntries(p, 0, cm)
+		for e := range cm {
+			if e.Rect != nil {
+				if e.Rect.ContainsPoint(p) {
+					m[e] = struct{}{}
+				}
+				continue
+			}
+			for _, c := range e.caps {
+				if c.ContainsPoint(p) {
+					m[e] = struct{}{}
+				}
+			}
+		}
+	}
+	return sortEntries(m)
+}
+
+// Intersecting returns entries which intersect g.
+func (idx *Index) Intersecting(g Geometry) []*IndexEntry {
+	idx.rebuild()
+	cm := make(map[*IndexEntry]struct{})
+	rc := &s2.RegionCoverer{MaxLevel: 30, MaxCells: 8}
+	for _, part := range geoParts(g) {
+		var region s2.Region
+		switch part := part.(type) {
+		case s2.Point:
+			idx.findEntries(part, pointTolerance, cm)
+			continue
+		case s2.Polyline:
+			region = &part
+		case *s2.Polygon:
+			region = part
+		case s2.Cap:
+			region = part
+		}
+		for _, id := range rc.Covering(region) {
+			idx.findEntries(s2.CellFromCellID(id), pointTolerance, cm)
+		}
+	}
+	m := make(map[*IndexEntry]struct{})
+	for e := range cm {
+		if e.intersects(g) {
+			m[e] = struct{}{}
+		}
+	}
+	return sortEntries(m)
+}
+
+// WithinDistance returns entries which distance from latlong is less than or equal to km,
+// in order of distance.
+func (idx *Index) WithinDistance(latlong Point, km Km) []*IndexEntry {
+	idx.rebuild()
+	p := latlong.S2Point()
+	cm := make(map[*IndexEntry]struct{})
+	idx.findEntries(p, km.EarthAngle(), cm)
+	ds := make(map[*IndexEntry]s1.Angle)
+	m := make(map[*IndexEntry]struct{})
+	for e := range cm {
+		if d := e.distance(p); d <= km.EarthAngle() {
+			m[e] = struct{}{}
+			ds[e] = d
+		}
+	}
+	es := sortEntries(m)
+	sort.SliceStable(es, func(i, j int) bool {
+		return ds[es[i]] < ds[es[j]]
+	})
+	return es
+}
+
+// IndexNeighbor is result of Nearest.
+type IndexNeighbor struct {
+	*IndexEntry
+	Distance Km
+	Closest  Point // the closest point on the geometry, which is the query point if it is inside.
+}
+
+// NewPointIndex returns Index of cds, which payloads are indexes of cds.
+func NewPointIndex(cds MultiPoint) *Index {
+	idx := NewIndex()
+	for i := range cds {
+		idx.Add(cds[i], i)
+	}
+	return idx
+}
+
+// neighbor returns distance from p to the edge or the interior of r.
+func (idx *Index) neighbor(p s2.Point, r s2.EdgeQueryResult) (e *IndexEntry, d s1.Angle, closest s2.Point) {
+	shape := idx.index.Shape(r.ShapeID())
+	e = idx.shapes[shape]
+	if r.IsInterior() {
+		return e, 0, p
+	}
+	if _, ok := e.caps[shape]; ok {
+		closest = e.closest(p, shape)
+		return e, p.Distance(closest), closest
+	}
+	edge := shape.Edge(int(r.EdgeID()))
+	closest = s2.Project(p, edge.V0, edge.V1)
+	return e, p.Distance(closest), closest
+}
+
+// Nearest returns k entries nearest to latlong within max km in order of distance,
+// by closest edge query of S2. Distance to Polygon and Circle is 0 if latlong is inside.
+// All entries are returned if k <= 0, and max is not limited if max <= 0.
+func (idx *Index) Nearest(latlong Point, k int, max Km) []IndexNeighbor {
+	idx.rebuild()
+	p := latlong.S2Point()
+	limit := s1.InfChordAngle()
+	if max > 0 {
+		limit = s1.ChordAngleFromAngle(max.EarthAngle() + idx.maxRadius).Successor()
+	}
+
+	for n := k; ; n *= 2 {
+		opts := s2.NewClosestEdgeQueryOptions().IncludeInteriors(true).DistanceLimit(limit)
+		if k > 0 {
+			opts = opts.MaxResults(n)
+		}
+		rs := s2.NewClosestEdgeQuery(idx.index, opts).FindEdges(s2.NewMinDistanceToPointTarget(p))
+
+		best := make(map[*IndexEntry]IndexNeighbor)
+		for _, r := range rs {
+			e, d, closest := idx.neighbor(p, r)
+			if e == nil || (max > 0 && d > max.EarthAngle()) {
+				continue
+			}
+			if nb, ok := best[e]; !ok || EarthArcFromAngle(d) < nb.Distance {
+				best[e] = IndexNeighbor{IndexEntry: e, Distance: EarthArcFromAngle(d), Closest: NewPointFromS2Point(closest).WithDatum(latlong.datum)}
+			}
+		}
+		nbs := make([]IndexNeighbor, 0, len(best))
+		for _, nb := range best {
+			nbs = append(nbs, nb)
+		}
+		sort.Slice(nbs, func(i, j int) bool {
+			if nbs[i].Distance != nbs[j].Distance {
+				return nbs[i].Distance < nbs[j].Distance
+			}
+			return nbs[i].seq < nbs[j].seq
+		})
+
+		if k <= 0 || len(rs) < n {
+			if k > 0 && len(nbs) > k {
+				nbs = nbs[:k]
+			}
+			return nbs
+		}
+		// entries not found have distance at least bound.
+		bound := EarthArcFromAngle(rs[len(rs)-1].Distance().Angle() - idx.maxRadius)
+		if len(nbs) >= k && nbs[k-1].Distance <= bound {
+			return nbs[:k]
+		}
+	}
+}
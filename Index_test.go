@@ -0,0 +1,152 @@
+package latlong_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/golang/geo/s1"
+
+	latlong "github.com/toyo/go-latlong"
+)
+
+func indexPayloads(es []*latlong.IndexEntry) (ps []string) {
+	for _, e := range es {
+		ps = append(ps, e.Payload.(string))
+	}
+	return
+}
+
+func TestIndex(t *testing.T) {
+	pt := func(lat, lng float64) latlong.Point {
+		return latlong.NewPoint(latlong.NewAngle(lat, 0), latlong.NewAngle(lng, 0), nil)
+	}
+	idx := latlong.NewIndex()
+	square := idx.Add(latlong.NewPolygon(latlong.LineString{MultiPoint: latlong.MultiPoint{pt(0, 0), pt(0, 2), pt(2, 2), pt(2, 0)}},
+		latlong.LineString{MultiPoint: latlong.MultiPoint{pt(0.5, 0.5), pt(0.5, 1.5), pt(1.5, 1.5), pt(1.5, 0.5)}}), "square")
+	idx.AddRect(latlong.NewRect(1, 2.5, 2, 2), "rect")
+	idx.Add(*latlong.NewCircle(pt(1, 1), 30), "circle")
+	idx.Add(pt(10, 10), "point")
+	idx.Add(latlong.LineString{MultiPoint: latlong.MultiPoint{pt(-1, 0), pt(-1, 4)}}, "line")
+	if idx.Len() != 5 {
+		t.Errorf("was %d", idx.Len())
+	}
+
+	for _, c := range []struct {
+		p     latlong.Point
+		expct []string
+	}{
+		{pt(0.2, 0.2), []string{"square"}},
+		{pt(1, 1), []string{"circle"}},
+		{pt(1, 1.9), []string{"square", "rect"}},
+		{pt(1, 3), []string{"rect"}},
+		{pt(10, 10), nil},
+	} {
+		if ps := indexPayloads(idx.ContainingPoint(c.p)); strings.Join(ps, ",") != strings.Join(c.expct, ",") {
+			t.Errorf("ContainingPoint %v expected %v, was %v", c.p, c.expct, ps)
+		}
+	}
+
+	cross := latlong.LineString{MultiPoint: latlong.MultiPoint{pt(-2, 0.2), pt(0.2, 0.2)}}
+	if ps := indexPayloads(idx.Intersecting(cross)); len(ps) != 2 || ps[0] != "square" || ps[1] != "line" {
+		t.Errorf("was %v", ps)
+	}
+	if ps := indexPayloads(idx.Intersecting(pt(10, 10))); len(ps) != 1 || ps[0] != "point" {
+		t.Errorf("was %v", ps)
+	}
+
+	if ps := indexPayloads(idx.WithinDistance(pt(-1.5, 1), 200)); len(ps) != 3 || ps[0] != "line" || ps[1] != "square" || ps[2] != "rect" {
+		t.Errorf("was %v", ps)
+	}
+	if ps := indexPayloads(idx.WithinDistance(pt(10, 10.5), 100)); len(ps) != 1 || ps[0] != "point" {
+		t.Errorf("was %v", ps)
+	}
+
+	idx.Remove(square)
+	idx.Remove(square)
+	if ps := indexPayloads(idx.ContainingPoint(pt(0.2, 0.2))); len(ps) != 0 || idx.Len() != 4 {
+		t.Errorf("was %v", ps)
+	}
+	if ps := indexPayloads(idx.ContainingPoint(pt(1, 1.9))); len(ps) != 1 || ps[0] != "rect" {
+		t.Errorf("was %v", ps)
+	}
+
+	idx.Add(latlong.NewPolygon(latlong.LineString{MultiPoint: latlong.MultiPoint{pt(5, 5), pt(5, 6), pt(6, 6), pt(6, 5)}}), "added")
+	if ps := indexPayloads(idx.ContainingPoint(pt(5.5, 5.5))); len(ps) != 1 || ps[0] != "added" {
+		t.Errorf("was %v", ps)
+	}
+
+	// edges of latitude of large rect are far from great circles.
+	large := latlong.NewRect(50, 30, 20, 60)
+	e := idx.AddRect(large, "large")
+	if e.Rect != large || e.Geometry != nil {
+		t.Errorf("was %#v", e)
+	}
+	for _, c := range []struct {
+		p     latlong.Point
+		expct []string
+	}{
+		{pt(42, 30), []string{"large"}},
+		{pt(61, 30), nil},
+	} {
+		if ps := indexPayloads(idx.ContainingPoint(c.p)); strings.Join(ps, ",") != strings.Join(c.expct, ",") {
+			t.Errorf("ContainingPoint %v expected %v, was %v", c.p, c.expct, ps)
+		}
+	}
+	if nbs := idx.Nearest(pt(61, 30), 1, 0); len(nbs) != 1 || nbs[0].Payload != "large" ||
+		math.Abs(float64(nbs[0].Distance)-111.2) > 0.1 || math.Abs(nbs[0].Closest.Lat().Degrees()-60) > 1e-9 {
+		t.Errorf("was %v", nbs)
+	}
+	if ps := indexPayloads(idx.WithinDistance(pt(61, 30), 112)); len(ps) != 1 || ps[0] != "large" {
+		t.Errorf("was %v", ps)
+	}
+	if ps := indexPayloads(idx.Intersecting(pt(42, 30))); len(ps) != 1 || ps[0] != "large" {
+		t.Errorf("was %v", ps)
+	}
+}
+
+func TestIndexNearest(t *testing.T) {
+	pt := func(lat, lng float64) latlong.Point {
+		return latlong.NewPoint(latlong.NewAngle(lat, 0), latlong.NewAngle(lng, 0), nil)
+	}
+	stations := latlong.MultiPoint{pt(35.6, 139.7), pt(34.7, 135.5), pt(43.1, 141.3), pt(26.2, 127.7), pt(35.2, 136.9)}
+	idx := latlong.NewPointIndex(stations)
+	epicentre := pt(35.0, 137.0)
+
+	nbs := idx.Nearest(epicentre, 3, 0)
+	if len(nbs) != 3 || nbs[0].Payload != 4 || nbs[1].Payload != 1 || nbs[2].Payload != 0 {
+		t.Fatalf("was %v", nbs)
+	}
+	for _, nb := range nbs {
+		if d := epicentre.DistanceEarthKm(&stations[nb.Payload.(int)]); math.Abs(float64(d-nb.Distance)) > 1e-6 {
+			t.Errorf("expected %v, was %v", d, nb.Distance)
+		}
+	}
+	if nbs := idx.Nearest(epicentre, 10, 200); len(nbs) != 2 {
+		t.Errorf("was %v", nbs)
+	}
+	if nbs := idx.Nearest(epicentre, 0, 0); len(nbs) != len(stations) || nbs[4].Payload != 3 {
+		t.Errorf("was %v", nbs)
+	}
+
+	// edges
+	idx = latlong.NewIndex()
+	idx.Add(latlong.LineString{MultiPoint: latlong.MultiPoint{pt(0, 0), pt(0, 10)}}, "equator")
+	idx.Add(latlong.NewPolygon(latlong.LineString{MultiPoint: latlong.MultiPoint{pt(1, 1), pt(1, 2), pt(2, 2), pt(2, 1)}}), "square")
+	idx.Add(*latlong.NewCircle(pt(-3, 5), 100), "circle")
+	nbs = idx.Nearest(pt(1.5, 1.5), 0, 0)
+	if len(nbs) != 3 || nbs[0].Payload != "square" || nbs[0].Distance != 0 || nbs[1].Payload != "equator" || nbs[2].Payload != "circle" {
+		t.Fatalf("was %v", nbs)
+	}
+	if math.Abs(float64(nbs[1].Distance-latlong.EarthArcFromAngle(1.5*s1.Degree))) > 0.01 ||
+		math.Abs(nbs[1].Closest.Lat().Degrees()) > 1e-9 || math.Abs(nbs[1].Closest.Lng().Degrees()-1.5) > 1e-3 {
+		t.Errorf("was %v %v", nbs[1].Distance, nbs[1].Closest)
+	}
+	nbs = idx.Nearest(pt(-5, 5), 1, 0)
+	if len(nbs) != 1 || nbs[0].Payload != "circle" {
+		t.Fatalf("was %v", nbs)
+	}
+	if d := latlong.EarthArcFromAngle(2*s1.Degree) - 100; math.Abs(float64(nbs[0].Distance-d)) > 0.01 {
+		t.Errorf("expected %v, was %v", d, nbs[0].Distance)
+	}
+}
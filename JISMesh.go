@@ -0,0 +1,174 @@
+package latlong
+
+import (
+	"errors"
+	"math"
+
+	"github.com/golang/geo/s1"
+)
+
+// Levels of JIS X 0410 regional mesh (地域メッシュ) by number of digits.
+const (
+	JISMesh1       = 4  // 1st mesh, 40' x 1deg (about 80km).
+	JISMesh2       = 6  // 2nd mesh, 5' x 7.5' (about 10km).
+	JISMesh3       = 8  // 3rd mesh, 30" x 45" (about 1km).
+	JISMeshHalf    = 9  // 1/2 mesh, about 500m.
+	JISMeshQuarter = 10 // 1/4 mesh, about 250m.
+	JISMeshEighth  = 11 // 1/8 mesh, about 125m.
+)
+
+// Latitude unit is 1/8 mesh, 3.75" = 1/960 deg. Longitude unit is 5.625" = 1/640 deg.
+const (
+	jisMeshLatUnits = 960
+	jisMeshLngUnits = 640
+)
+
+// jisMeshUnits returns size of a mesh in units for each level.
+func jisMeshUnits(level int) int {
+	switch level {
+	case JISMesh1:
+		return 640
+	case JISMesh2:
+		return 80
+	case JISMesh3:
+		return 8
+	case JISMeshHalf:
+		return 4
+	case JISMeshQuarter:
+		return 2
+	case JISMeshEighth:
+		return 1
+	}
+	return 0
+}
+
+// NewRectJISMesh is from JIS X 0410 regional mesh code.
+// https://www.stat.go.jp/data/mesh/m_tuite.html
+func NewRectJISMesh(code string) (*Rect, error) {
+	lat, lng, size, err := jisMeshIndex(code)
+	if err != nil {
+		return nil, err
+	}
+	latsize := float64(size) / jisMeshLatUnits
+	lngsize := float64(size) / jisMeshLngUnits
+	return NewRect(
+		float64(lat)/jisMeshLatUnits+latsize/2,
+		float64(lng)/jisMeshLngUnits+100+lngsize/2,
+		latsize, lngsize), nil
+}
+
+// jisMeshIndex returns south-west corner and size of mesh code in units.
+func jisMeshIndex(code string) (lat, lng, size int, err error) {
+	size = jisMeshUnits(len(code))
+	if size == 0 {
+		return 0, 0, 0, errors.New("JIS mesh code length error")
+	}
+	for _, c := range code {
+		if c < '0' || '9' < c {
+			return 0, 0, 0, errors.New("JIS mesh code is not digit")
+		}
+	}
+	digit := func(i int) int {
+		return int(code[i] - '0')
+	}
+
+	lat = (digit(0)*10 + digit(1)) * 640
+	lng = (digit(2)*10 + digit(3)) * 640
+
+	if len(code) >= JISMesh2 {
+		if digit(4) > 7 || digit(5) > 7 {
+			return 0, 0, 0, errors.New("JIS 2nd mesh code error")
+		}
+		lat += digit(4) * 80
+		lng += digit(5) * 80
+	}
+	if len(code) >= JISMesh3 {
+		lat += digit(6) * 8
+		lng += digit(7) * 8
+	}
+	for i, u := JISMesh3, 4; i < len(code); i, u = i+1, u/2 {
+		m := digit(i) - 1
+		if m < 0 || m > 3 {
+			return 0, 0, 0, errors.New("JIS divided mesh code error")
+		}
+		lat += m / 2 * u
+		lng += m % 2 * u
+	}
+	return
+}
+
+// jisMesh returns mesh code of level at lat, lng in degrees.
+// It returns empty string if out of range.
+func jisMesh(lat, lng float64, level int) string {
+	latidx := int(math.Floor(lat * jisMeshLatUnits))
+	lngidx := int(math.Floor((lng - 100) * jisMeshLngUnits))
+	if latidx < 0 || latidx >= 100*640 || lngidx < 0 || lngidx >= 100*640 {
+		return ""
+	}
+
+	b := make([]byte, 0, JISMeshEighth)
+	b = appendDigits(b, int64(latidx/640), 2)
+	b = appendDigits(b, int64(lngidx/640), 2)
+	if level >= JISMesh2 {
+		b = append(b, byte('0'+latidx%640/80), byte('0'+lngidx%640/80))
+	}
+	if level >= JISMesh3 {
+		b = append(b, byte('0'+latidx%80/8), byte('0'+lngidx%80/8))
+	}
+	for i, u := JISMesh3, 4; i < level; i, u = i+1, u/2 {
+		b = append(b, byte('1'+latidx%(2*u)/u*2+lngidx%(2*u)/u))
+	}
+	return string(b)
+}
+
+// JISMesh returns JIS X 0410 regional mesh code.
+// The level is chosen from the size of rect,
+// and empty string is returned if rect is larger than 1st mesh or out of range.
+func (rect *Rect) JISMesh() string {
+	const floaterr = 1 + 1e-9
+
+	level := 0
+	for _, l := range []int{JISMesh1, JISMesh2, JISMesh3, JISMeshHalf, JISMeshQuarter, JISMeshEighth} {
+		u := float64(jisMeshUnits(l))
+		if u/jisMeshLatUnits*floaterr < rect.Size().Lat.Degrees() || u/jisMeshLngUnits*floaterr < rect.Size().Lng.Degrees() {
+			break
+		}
+		level = l
+	}
+	if level == 0 {
+		return ""
+	}
+	return jisMesh(rect.Center().Lat().Degrees(), rect.Center().Lng().Degrees(), level)
+}
+
+// JISMeshCodes returns all JIS X 0410 regional mesh codes of level covering g.
+func JISMeshCodes(g Geometry, level int) (codes []string, err error) {
+	size := jisMeshUnits(level)
+	if size == 0 {
+		return nil, errors.New("JIS mesh level error")
+	}
+	region := g.S2Region()
+	bound := region.RectBound()
+
+	lat0 := int(math.Floor(s1.Angle(bound.Lat.Lo).Degrees()*jisMeshLatUnits)) / size * size
+	lat1 := int(math.Floor(s1.Angle(bound.Lat.Hi).Degrees() * jisMeshLatUnits))
+	lng0 := int(math.Floor((s1.Angle(bound.Lng.Lo).Degrees()-100)*jisMeshLngUnits)) / size * size
+	lng1 := int(math.Floor((s1.Angle(bound.Lng.Hi).Degrees() - 100) * jisMeshLngUnits))
+
+	latsize := float64(size) / jisMeshLatUnits
+	lngsize := float64(size) / jisMeshLngUnits
+	for lat := lat0; lat <= lat1; lat += size {
+		for lng := lng0; lng <= lng1; lng += size {
+			clat := float64(lat)/jisMeshLatUnits + latsize/2
+			clng := float64(lng)/jisMeshLngUnits + 100 + lngsize/2
+			cell := NewRect(clat, clng, latsize, lngsize)
+			if !cell.IntersectsRegion(region) {
+				continue
+			}
+			if code := jisMesh(clat, clng, level); code != "" {
+				codes = append(codes, code)
+			}
+		}
+	}
+	return
+}
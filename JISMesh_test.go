@@ -0,0 +1,102 @@
+package latlong_test
+
+import (
+	"math/rand"
+	"testing"
+
+	latlong "github.com/toyo/go-latlong"
+)
+
+func TestJISMesh(t *testing.T) {
+	// Tokyo Station is in 5339-46-11 (3rd mesh).
+	tokyo := latlong.NewRect(35.681236, 139.767125, 0, 0)
+	for _, tt := range []struct {
+		level int
+		expct string
+	}{
+		{latlong.JISMesh1, "5339"},
+		{latlong.JISMesh2, "533946"},
+		{latlong.JISMesh3, "53394611"},
+	} {
+		codes, err := latlong.JISMeshCodes(tokyo.Center(), tt.level)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(codes) != 1 || codes[0] != tt.expct {
+			t.Errorf("expected %v, was %v", tt.expct, codes)
+		}
+	}
+}
+
+func randomJISMesh(n int) string {
+	b := []byte{byte('3' + rand.Intn(4)), byte('0' + rand.Intn(10)), byte('2' + rand.Intn(4)), byte('0' + rand.Intn(10))}
+	if n >= 6 {
+		b = append(b, byte('0'+rand.Intn(8)), byte('0'+rand.Intn(8)))
+	}
+	if n >= 8 {
+		b = append(b, byte('0'+rand.Intn(10)), byte('0'+rand.Intn(10)))
+	}
+	for len(b) < n {
+		b = append(b, byte('1'+rand.Intn(4)))
+	}
+	return string(b)
+}
+
+func TestJISMeshRoundTrip(t *testing.T) {
+	randInit()
+
+	for _, n := range []int{4, 6, 8, 9, 10, 11} {
+		code := randomJISMesh(n)
+		r, err := latlong.NewRectJISMesh(code)
+		if err != nil {
+			t.Errorf("%s: %v", code, err)
+			continue
+		}
+		if c := r.JISMesh(); c != code {
+			t.Errorf("expected %s, was %s", code, c)
+		}
+	}
+
+	for _, code := range []string{"533", "53394", "5339a6", "53398611", "533946115"} {
+		if _, err := latlong.NewRectJISMesh(code); err == nil {
+			t.Errorf("%s: expected error", code)
+		}
+	}
+}
+
+func TestJISMeshCodes(t *testing.T) {
+	r, _ := latlong.NewRectJISMesh("533946")
+	ls := latlong.LineString{MultiPoint: latlong.MultiPoint{
+		latlong.NewPoint(latlong.NewAngle(35.67, 0), latlong.NewAngle(139.76, 0), nil),
+		latlong.NewPoint(latlong.NewAngle(35.74, 0), latlong.NewAngle(139.76, 0), nil),
+	}}
+	codes, err := latlong.JISMeshCodes(ls, latlong.JISMesh3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != 9 {
+		t.Errorf("expected 9 meshes, was %v", codes)
+	}
+
+	// slightly smaller than 2nd mesh 533946.
+	lo, hi := r.Lo(), r.Hi()
+	const e = 1e-4
+	pg := latlong.NewPolygon(latlong.LineString{MultiPoint: latlong.MultiPoint{
+		latlong.NewPoint(latlong.NewAngle(lo.Lat.Degrees()+e, 0), latlong.NewAngle(lo.Lng.Degrees()+e, 0), nil),
+		latlong.NewPoint(latlong.NewAngle(lo.Lat.Degrees()+e, 0), latlong.NewAngle(hi.Lng.Degrees()-e, 0), nil),
+		latlong.NewPoint(latlong.NewAngle(hi.Lat.Degrees()-e, 0), latlong.NewAngle(hi.Lng.Degrees()-e, 0), nil),
+		latlong.NewPoint(latlong.NewAngle(hi.Lat.Degrees()-e, 0), latlong.NewAngle(lo.Lng.Degrees()+e, 0), nil),
+	}})
+	codes, err = latlong.JISMeshCodes(pg, latlong.JISMesh3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != 100 {
+		t.Errorf("expected 100 meshes, was %d", len(codes))
+	}
+	for _, c := range codes {
+		if c[:6] != "533946" {
+			t.Errorf("unexpected mesh %s", c)
+		}
+	}
+}
@@ -0,0 +1,509 @@
+package latlong
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// KMLStyle is Style of KML. Colors are aabbggrr in hex.
+type KMLStyle struct {
+	ID        string
+	LineColor string
+	LineWidth float64
+	PolyColor string
+	IconHref  string
+}
+
+const kmlNamespace = "http://www.opengis.net/kml/2.2"
+
+// Properties of feature which are mapped to elements of Placemark instead of ExtendedData.
+const (
+	kmlName         = "name"
+	kmlDescription  = "description"
+	kmlStyleURL     = "styleUrl"
+	kmlAltitudeMode = "altitudeMode"
+)
+
+type kmlXML struct {
+	XMLName  xml.Name    `xml:"kml"`
+	Xmlns    string      `xml:"xmlns,attr"`
+	Document kmlDocument `xml:"Document"`
+}
+
+type kmlDocument struct {
+	Styles     []kmlStyle     `xml:"Style"`
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlStyle struct {
+	ID        string `xml:"id,attr,omitempty"`
+	IconStyle *struct {
+		Href string `xml:"Icon>href"`
+	} `xml:"IconStyle,omitempty"`
+	LineStyle *struct {
+		Color string  `xml:"color,omitempty"`
+		Width float64 `xml:"width,omitempty"`
+	} `xml:"LineStyle,omitempty"`
+	PolyStyle *struct {
+		Color string `xml:"color,omitempty"`
+	} `xml:"PolyStyle,omitempty"`
+}
+
+type kmlPlacemark struct {
+	Name         string           `xml:"name,omitempty"`
+	Description  string           `xml:"description,omitempty"`
+	StyleURL     string           `xml:"styleUrl,omitempty"`
+	ExtendedData *kmlExtendedData `xml:"ExtendedData,omitempty"`
+	kmlMultiGeometry
+	MultiGeometry *kmlMultiGeometry `xml:"MultiGeometry,omitempty"`
+}
+
+type kmlExtendedData struct {
+	Data []struct {
+		Name  string `xml:"name,attr"`
+		Value string `xml:"value"`
+	} `xml:"Data"`
+	SchemaData *struct {
+		SimpleData []struct {
+			Name  string `xml:"name,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"SimpleData"`
+	} `xml:"SchemaData,omitempty"`
+}
+
+// kmlMultiGeometry is also used for a geometry of Placemark.
+type kmlMultiGeometry struct {
+	Points        []kmlCoordinates   `xml:"Point,omitempty"`
+	LineStrings   []kmlCoordinates   `xml:"LineString,omitempty"`
+	Polygons      []kmlPolygon       `xml:"Polygon,omitempty"`
+	MultiGeometry []kmlMultiGeometry `xml:"MultiGeometry,omitempty"`
+}
+
+type kmlCoordinates struct {
+	AltitudeMode string `xml:"altitudeMode,omitempty"`
+	Coordinates  string `xml:"coordinates"`
+}
+
+type kmlPolygon struct {
+	AltitudeMode string         `xml:"altitudeMode,omitempty"`
+	Outer        kmlCoordinates `xml:"outerBoundaryIs>LinearRing"`
+	Inner        []kmlInnerRing `xml:"innerBoundaryIs,omitempty"`
+}
+
+type kmlInnerRing struct {
+	Ring kmlCoordinates `xml:"LinearRing"`
+}
+
+// WriteKML encodes fc to w as KML 2.2.
+// Properties "name", "description", "styleUrl" and "altitudeMode" are mapped to elements of Placemark,
+// and others are in ExtendedData. altitudeMode is "absolute" if it is not specified and points have altitude.
+// GeometryCollection is written as MultiGeometry grouped by type.
+func (g *GeoJSONFeatureCollection) WriteKML(w io.Writer, styles ...KMLStyle) error {
+	k := kmlXML{Xmlns: kmlNamespace}
+	for _, s := range styles {
+		k.Document.Styles = append(k.Document.Styles, s.kmlStyle())
+	}
+	for _, f := range g.Features {
+		pm, err := newKMLPlacemark(f)
+		if err != nil {
+			return err
+		}
+		k.Document.Placemarks = append(k.Document.Placemarks, pm)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", " ")
+	if err := enc.Encode(&k); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteKMZ encodes fc to w as KMZ, which is zipped doc.kml.
+func (g *GeoJSONFeatureCollection) WriteKMZ(w io.Writer, styles ...KMLStyle) error {
+	zw := zip.NewWriter(w)
+	f, err := zw.Create("doc.kml")
+	if err != nil {
+		return err
+	}
+	if err := g.WriteKML(f, styles...); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func (s KMLStyle) kmlStyle() (k kmlStyle) {
+	k.ID = s.ID
+	if s.IconHref != "" {
+		k.IconStyle = &struct {
+			Href string `xml:"Icon>href"`
+		}{s.IconHref}
+	}
+	if s.LineColor != "" || s.LineWidth != 0 {
+		k.LineStyle = &struct {
+			Color string  `xml:"color,omitempty"`
+			Width float64 `xml:"width,omitempty"`
+		}{s.LineColor, s.LineWidth}
+	}
+	if s.PolyColor != "" {
+		k.PolyStyle = &struct {
+			Color string `xml:"color,omitempty"`
+		}{s.PolyColor}
+	}
+	return
+}
+
+func newKMLPlacemark(f GeoJSONFeature) (pm kmlPlacemark, err error) {
+	prop, err := propertiesMap(f.Property)
+	if err != nil {
+		return
+	}
+	altmode := ""
+	keys := make([]string, 0, len(prop))
+	for k := range prop {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := prop[k]
+		s, ok := v.(string)
+		if !ok {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return pm, err
+			}
+			s = string(b)
+		}
+		switch k {
+		case kmlName:
+			pm.Name = s
+		case kmlDescription:
+			pm.Description = s
+		case kmlStyleURL:
+			pm.StyleURL = s
+		case kmlAltitudeMode:
+			altmode = s
+		default:
+			if pm.ExtendedData == nil {
+				pm.ExtendedData = new(kmlExtendedData)
+			}
+			pm.ExtendedData.Data = append(pm.ExtendedData.Data, struct {
+				Name  string `xml:"name,attr"`
+				Value string `xml:"value"`
+			}{k, s})
+		}
+	}
+
+	if f.Geometry == nil || f.Geometry.Geo() == nil {
+		return
+	}
+	if altmode == "" && wktHasZ(geometryPoints(f.Geometry.Geo())) {
+		altmode = "absolute"
+	}
+	var mg kmlMultiGeometry
+	if err = mg.add(f.Geometry.Geo(), altmode); err != nil {
+		return
+	}
+	if len(mg.Points)+len(mg.LineStrings)+len(mg.Polygons) == 1 && len(mg.MultiGeometry) == 0 {
+		pm.kmlMultiGeometry = mg
+	} else if len(mg.MultiGeometry) == 1 && len(mg.Points)+len(mg.LineStrings)+len(mg.Polygons) == 0 {
+		pm.MultiGeometry = &mg.MultiGeometry[0]
+	} else {
+		pm.MultiGeometry = &mg
+	}
+	return
+}
+
+// add adds g into mg. Multi geometries are added as MultiGeometry.
+func (mg *kmlMultiGeometry) add(g Geometry, altmode string) error {
+	switch g := g.(type) {
+	case Point:
+		mg.Points = append(mg.Points, kmlCoordinates{altmode, kmlCoordinatesString(MultiPoint{g})})
+	case LineString:
+		mg.LineStrings = append(mg.LineStrings, kmlCoordinates{altmode, kmlCoordinatesString(g.MultiPoint)})
+	case Polygon:
+		p := kmlPolygon{AltitudeMode: altmode}
+		p.Outer.Coordinates = kmlCoordinatesString(kmlRing(g.MultiPoint))
+		for _, h := range g.Holes {
+			p.Inner = append(p.Inner, kmlInnerRing{Ring: kmlCoordinates{Coordinates: kmlCoordinatesString(kmlRing(h.MultiPoint))}})
+		}
+		mg.Polygons = append(mg.Polygons, p)
+	case MultiPoint, MultiLineString, MultiPolygon, GeometryCollection:
+		var sub kmlMultiGeometry
+		for _, gg := range kmlGeometries(g) {
+			if err := sub.add(gg, altmode); err != nil {
+				return err
+			}
+		}
+		mg.MultiGeometry = append(mg.MultiGeometry, sub)
+	case nil:
+	default:
+		return errors.New("KML unsupported type " + g.Type())
+	}
+	return nil
+}
+
+// kmlGeometries returns elements of multi geometry.
+func kmlGeometries(g Geometry) (gs []Geometry) {
+	switch g := g.(type) {
+	case MultiPoint:
+		for i := range g {
+			gs = append(gs, g[i])
+		}
+	case MultiLineString:
+		for i := range g {
+			gs = append(gs, g[i])
+		}
+	case MultiPolygon:
+		for i := range g {
+			gs = append(gs, g[i])
+		}
+	case GeometryCollection:
+		return g
+	}
+	return
+}
+
+// kmlRing returns closed ring, as LinearRing of KML must be closed.
+func kmlRing(cds MultiPoint) MultiPoint {
+	if len(cds) == 0 || (cds[0].lat == cds[len(cds)-1].lat && cds[0].lng == cds[len(cds)-1].lng) {
+		return cds
+	}
+	return append(cds[:len(cds):len(cds)], cds[0])
+}
+
+func kmlCoordinatesString(cds MultiPoint) string {
+	var b []byte
+	for i, p := range cds {
+		if i > 0 {
+			b = append(b, ' ')
+		}
+		b = append(b, p.lng.String()...)
+		b = append(b, ',')
+		b = append(b, p.lat.String()...)
+		if p.alt != nil {
+			b = append(b, ',')
+			b = strconv.AppendFloat(b, *p.alt, 'f', -1, 64)
+		}
+	}
+	return string(b)
+}
+
+// ReadKML decodes Placemarks in KML from r, including ones in nested Document and Folder.
+// name, description, styleUrl and ExtendedData are in properties.
+// Altitude is dropped if altitudeMode is clampToGround (default) or clampToSeaFloor,
+// and altitudeMode is in properties if it is relative.
+func ReadKML(r io.Reader) (*GeoJSONFeatureCollection, error) {
+	fc := NewGeoJSONFeatureCollection()
+	dec := xml.NewDecoder(r)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok || se.Name.Local != "Placemark" {
+			continue
+		}
+		var pm kmlPlacemark
+		if err := dec.DecodeElement(&pm, &se); err != nil {
+			return nil, err
+		}
+		f, err := pm.feature()
+		if err != nil {
+			return nil, err
+		}
+		fc.Features = append(fc.Features, f)
+	}
+	return fc, nil
+}
+
+// ReadKMZ decodes KMZ, which is zipped KML. The first .kml file in the archive is read.
+func ReadKMZ(r io.ReaderAt, size int64) (*GeoJSONFeatureCollection, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range zr.File {
+		if strings.ToLower(path.Ext(f.Name)) != ".kml" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return ReadKML(rc)
+	}
+	return nil, errors.New("No KML in KMZ")
+}
+
+func (pm kmlPlacemark) feature() (f GeoJSONFeature, err error) {
+	f.Type = "Feature"
+	prop := make(map[string]interface{})
+	if pm.Name != "" {
+		prop[kmlName] = strings.TrimSpace(pm.Name)
+	}
+	if pm.Description != "" {
+		prop[kmlDescription] = strings.TrimSpace(pm.Description)
+	}
+	if pm.StyleURL != "" {
+		prop[kmlStyleURL] = strings.TrimSpace(pm.StyleURL)
+	}
+	if pm.ExtendedData != nil {
+		for _, d := range pm.ExtendedData.Data {
+			prop[d.Name] = d.Value
+		}
+		if pm.ExtendedData.SchemaData != nil {
+			for _, d := range pm.ExtendedData.SchemaData.SimpleData {
+				prop[d.Name] = d.Value
+			}
+		}
+	}
+	f.Property = prop
+
+	mg := pm.kmlMultiGeometry
+	if pm.MultiGeometry != nil {
+		mg.MultiGeometry = append(mg.MultiGeometry, *pm.MultiGeometry)
+	}
+	gs, altmode, err := mg.geometries()
+	if err != nil {
+		return
+	}
+	if altmode == "relativeToGround" || altmode == "relativeToSeaFloor" {
+		prop[kmlAltitudeMode] = altmode
+	}
+	switch len(gs) {
+	case 0:
+	case 1:
+		f.Geometry = NewGeoJSONGeometry(gs[0]).pointer()
+	default:
+		f.Geometry = NewGeoJSONGeometry(GeometryCollection(gs)).pointer()
+	}
+	return
+}
+
+func (geom GeoJSONGeometry) pointer() *GeoJSONGeometry {
+	return &geom
+}
+
+// geometries returns geometries in mg. altmode is of the last geometry.
+func (mg kmlMultiGeometry) geometries() (gs []Geometry, altmode string, err error) {
+	for _, c := range mg.Points {
+		cds, err := parseKMLCoordinates(c.Coordinates, c.AltitudeMode)
+		if err != nil || len(cds) != 1 {
+			return nil, "", errors.New("KML Point coordinates error")
+		}
+		gs = append(gs, cds[0])
+		altmode = c.AltitudeMode
+	}
+	for _, c := range mg.LineStrings {
+		cds, err := parseKMLCoordinates(c.Coordinates, c.AltitudeMode)
+		if err != nil {
+			return nil, "", err
+		}
+		gs = append(gs, LineString{MultiPoint: cds})
+		altmode = c.AltitudeMode
+	}
+	for _, p := range mg.Polygons {
+		outer, err := parseKMLCoordinates(p.Outer.Coordinates, p.AltitudeMode)
+		if err != nil {
+			return nil, "", err
+		}
+		var holes []LineString
+		for _, h := range p.Inner {
+			cds, err := parseKMLCoordinates(h.Ring.Coordinates, p.AltitudeMode)
+			if err != nil {
+				return nil, "", err
+			}
+			holes = append(holes, LineString{MultiPoint: cds})
+		}
+		gs = append(gs, NewPolygon(LineString{MultiPoint: outer}, holes...))
+		altmode = p.AltitudeMode
+	}
+	for _, sub := range mg.MultiGeometry {
+		subgs, subaltmode, err := sub.geometries()
+		if err != nil {
+			return nil, "", err
+		}
+		gs = append(gs, kmlMulti(subgs))
+		altmode = subaltmode
+	}
+	return
+}
+
+// kmlMulti returns MultiPoint, MultiLineString or MultiPolygon if gs are same type,
+// otherwise GeometryCollection.
+func kmlMulti(gs []Geometry) Geometry {
+	var mp MultiPoint
+	var mls MultiLineString
+	var mpg MultiPolygon
+	for _, g := range gs {
+		switch g := g.(type) {
+		case Point:
+			mp = append(mp, g)
+		case LineString:
+			mls = append(mls, g)
+		case Polygon:
+			mpg = append(mpg, g)
+		}
+	}
+	switch len(gs) {
+	case len(mp):
+		return mp
+	case len(mls):
+		return mls
+	case len(mpg):
+		return mpg
+	}
+	return GeometryCollection(gs)
+}
+
+func parseKMLCoordinates(s string, altmode string) (cds MultiPoint, err error) {
+	clamp := altmode == "" || altmode == "clampToGround" || altmode == "clampToSeaFloor"
+	for _, tuple := range strings.Fields(s) {
+		ords := strings.Split(tuple, ",")
+		if len(ords) < 2 || len(ords) > 3 {
+			return nil, fmt.Errorf("KML coordinates error %q", tuple)
+		}
+		var p Point
+		p.lng = AngleFromBytes([]byte(ords[0]))
+		p.lat = AngleFromBytes([]byte(ords[1]))
+		if isErrorDeg(p.lng) || isErrorDeg(p.lat) {
+			return nil, fmt.Errorf("KML coordinates error %q", tuple)
+		}
+		if len(ords) == 3 && !clamp {
+			altitude, err := strconv.ParseFloat(ords[2], 64)
+			if err != nil {
+				return nil, fmt.Errorf("KML coordinates error %q", tuple)
+			}
+			p.alt = &altitude
+		}
+		p.datum = DatumWGS84
+		cds = append(cds, p)
+	}
+	return cds, nil
+}
+
+// ReadKMLBytes decodes KML or KMZ by checking zip signature.
+func ReadKMLBytes(b []byte) (*GeoJSONFeatureCollection, error) {
+	if bytes.HasPrefix(b, []byte("PK\x03\x04")) {
+		return ReadKMZ(bytes.NewReader(b), int64(len(b)))
+	}
+	return ReadKML(bytes.NewReader(b))
+}
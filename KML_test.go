@@ -0,0 +1,122 @@
+package latlong_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	latlong "github.com/toyo/go-latlong"
+)
+
+const kmlstring = `<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2">
+ <Document>
+  <Folder>
+   <Placemark>
+    <name>Summit</name>
+    <ExtendedData><Data name="height"><value>3776</value></Data></ExtendedData>
+    <Point><altitudeMode>absolute</altitudeMode><coordinates>138.7274,35.3606,3776</coordinates></Point>
+   </Placemark>
+   <Placemark>
+    <name>Trail</name>
+    <ExtendedData><SchemaData schemaUrl="#trail"><SimpleData name="route">Yoshida</SimpleData></SchemaData></ExtendedData>
+    <LineString><coordinates>138.7330,35.3950,2305 138.7274,35.3606,3776</coordinates></LineString>
+   </Placemark>
+  </Folder>
+  <Placemark>
+   <styleUrl>#area</styleUrl>
+   <Polygon>
+    <outerBoundaryIs><LinearRing><coordinates>139,35 140,35 140,36 139,36 139,35</coordinates></LinearRing></outerBoundaryIs>
+    <innerBoundaryIs><LinearRing><coordinates>139.4,35.4 139.6,35.4 139.6,35.6 139.4,35.4</coordinates></LinearRing></innerBoundaryIs>
+   </Polygon>
+  </Placemark>
+  <Placemark>
+   <MultiGeometry>
+    <Point><coordinates>139,35</coordinates></Point>
+    <Point><coordinates>140,36</coordinates></Point>
+   </MultiGeometry>
+  </Placemark>
+ </Document>
+</kml>`
+
+func TestKML(t *testing.T) {
+	fc, err := latlong.ReadKML(strings.NewReader(kmlstring))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fc.Features) != 4 {
+		t.Fatalf("was %d features", len(fc.Features))
+	}
+	for i, typ := range []string{"Point", "LineString", "Polygon", "MultiPoint"} {
+		if fc.Features[i].Geometry.Geo().Type() != typ {
+			t.Errorf("expected %s, was %s", typ, fc.Features[i].Geometry.Geo().Type())
+		}
+	}
+
+	b, err := json.Marshal(fc.Features[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	expct := `{"type":"Feature","geometry":{"type":"Point","coordinates":[138.7274,35.3606,3776]},"properties":{"height":"3776","name":"Summit"}}`
+	if string(b) != expct {
+		t.Errorf("expected %s, was %s", expct, string(b))
+	}
+
+	// altitude is dropped by clampToGround.
+	if p := fc.Features[1].Geometry.Geo().(latlong.LineString).MultiPoint[0]; p.Alt() != nil {
+		t.Errorf("expected no altitude, was %v", *p.Alt())
+	}
+	if fc.Features[1].Property.(map[string]interface{})["route"] != "Yoshida" {
+		t.Errorf("was %v", fc.Features[1].Property)
+	}
+	if pg := fc.Features[2].Geometry.Geo().(latlong.Polygon); len(pg.Holes) != 1 ||
+		fc.Features[2].Property.(map[string]interface{})["styleUrl"] != "#area" {
+		t.Errorf("was %v", fc.Features[2])
+	}
+
+	// round trip
+	var kml bytes.Buffer
+	if err := fc.WriteKML(&kml, latlong.KMLStyle{ID: "area", LineColor: "ff0000ff", LineWidth: 2, PolyColor: "7f00ff00"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(kml.String(), `<Style id="area">`) || !strings.Contains(kml.String(), `<altitudeMode>absolute</altitudeMode>`) {
+		t.Errorf("was %s", kml.String())
+	}
+	fc1, err := latlong.ReadKML(&kml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range fc.Features {
+		b, _ := json.Marshal(fc.Features[i])
+		b1, _ := json.Marshal(fc1.Features[i])
+		if string(b) != string(b1) {
+			t.Errorf("round trip expected %s, was %s", string(b), string(b1))
+		}
+	}
+
+	if _, err := latlong.ReadKML(strings.NewReader(`<kml><Placemark><Point><coordinates>x,1</coordinates></Point></Placemark></kml>`)); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestKMZ(t *testing.T) {
+	fc, err := latlong.ReadKML(strings.NewReader(kmlstring))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var kmz bytes.Buffer
+	if err := fc.WriteKMZ(&kmz); err != nil {
+		t.Fatal(err)
+	}
+	fc1, err := latlong.ReadKMZ(bytes.NewReader(kmz.Bytes()), int64(kmz.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fc1.Features) != len(fc.Features) {
+		t.Errorf("expected %d features, was %d", len(fc.Features), len(fc1.Features))
+	}
+	if fc2, err := latlong.ReadKMLBytes(kmz.Bytes()); err != nil || len(fc2.Features) != len(fc.Features) {
+		t.Errorf("was %v %v", fc2, err)
+	}
+}
@@ -36,3 +36,15 @@ func EarthArcFromChordAngle(chordangle s1.ChordAngle) Km {
 func (km Km) String() string {
 	return strconv.FormatFloat(float64(km), 'f', 1, 64) + "km"
 }
+
+// Km2 is square kilo-meter.
+type Km2 float64
+
+// EarthAreaFromSteradian makes solid angle to Area.
+func EarthAreaFromSteradian(steradian float64) Km2 {
+	return Km2(steradian * radiusKmOfTheEarth * radiusKmOfTheEarth)
+}
+
+func (km2 Km2) String() string {
+	return strconv.FormatFloat(float64(km2), 'f', 1, 64) + "km2"
+}
@@ -27,6 +27,11 @@ func (cds *LineString) UnmarshalText(b []byte) error {
 	return cds.MultiPoint.UnmarshalText(b)
 }
 
+// MarshalText is to ISO6709 latlongs.
+func (cds LineString) MarshalText() ([]byte, error) {
+	return cds.MultiPoint.MarshalText()
+}
+
 // S2Polyline is getter for s2.Polyline ([]s2.Point).
 func (cds LineString) S2Polyline() s2.Polyline {
 	var ps s2.Polyline
@@ -107,11 +112,13 @@ func (cds LineString) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON is a unmarshaler for JSON.
 func (cds *LineString) UnmarshalJSON(data []byte) (err error) {
-	err = json.Unmarshal(data, &cds.MultiPoint)
-	if err != nil {
-		panic(err)
-	}
-	return nil
+	return json.Unmarshal(data, &cds.MultiPoint)
+}
+
+// Equal return bool
+func (cds LineString) Equal(c1 Geometry) bool {
+	c, ok := c1.(LineString)
+	return ok && cds.MultiPoint.Equal(c.MultiPoint)
 }
 
 // NewGeoJSONGeometry returns GeoJSONGeometry.
@@ -0,0 +1,191 @@
+package latlong
+
+import (
+	"math"
+
+	"github.com/golang/geo/s1"
+)
+
+// segmentLength returns length of cds by great circles, or by geodesics on e if e is not nil.
+// The closing segment is added if ring.
+func segmentLength(cds MultiPoint, ring bool, e *Ellipsoid) (km Km) {
+	n := len(cds)
+	if ring && n > 1 && cds[0].S2LatLng() != cds[n-1].S2LatLng() {
+		cds = append(cds[:n:n], cds[0])
+	}
+	for i := 0; i+1 < len(cds); i++ {
+		if e == nil {
+			km += cds[i].DistanceEarthKm(&cds[i+1])
+		} else {
+			d, _, _ := cds[i].GeodesicInverse(&cds[i+1], e)
+			km += d
+		}
+	}
+	return
+}
+
+// authalic returns authalic latitude of lat and radius of authalic sphere in km.
+func (e *Ellipsoid) authalic(lat s1.Angle) (s1.Angle, Km) {
+	if e.e2 == 0 {
+		return lat, Km(e.a / 1000)
+	}
+	ee := math.Sqrt(e.e2)
+	q := func(sinlat float64) float64 {
+		return (1 - e.e2) * (sinlat/(1-e.e2*sinlat*sinlat) + math.Atanh(ee*sinlat)/ee)
+	}
+	qp := q(1)
+	return s1.Angle(math.Asin(math.Max(-1, math.Min(1, q(math.Sin(float64(lat)))/qp)))),
+		Km(e.a / 1000 * math.Sqrt(qp/2))
+}
+
+// authalicRing returns cds on the authalic sphere of e.
+func (e *Ellipsoid) authalicRing(cds MultiPoint) LineString {
+	ring := make(MultiPoint, len(cds))
+	for i := range cds {
+		lat, _ := e.authalic(cds[i].lat.S1Angle())
+		ring[i] = Point{lat: NewAngleFromS1Angle(lat, 0), lng: cds[i].lng}
+	}
+	return LineString{MultiPoint: ring}
+}
+
+// Length returns length of cds by great circles on the sphere.
+func (cds LineString) Length() Km {
+	return segmentLength(cds.MultiPoint, false, nil)
+}
+
+// LengthEllipsoid returns length of cds by geodesics on ellipsoid e.
+func (cds LineString) LengthEllipsoid(e *Ellipsoid) Km {
+	return segmentLength(cds.MultiPoint, false, e)
+}
+
+// Length returns sum of length of cds on the sphere.
+func (cds MultiLineString) Length() (km Km) {
+	for i := range cds {
+		km += cds[i].Length()
+	}
+	return
+}
+
+// LengthEllipsoid returns sum of length of cds on ellipsoid e.
+func (cds MultiLineString) LengthEllipsoid(e *Ellipsoid) (km Km) {
+	for i := range cds {
+		km += cds[i].LengthEllipsoid(e)
+	}
+	return
+}
+
+// Area returns area excluding holes on the sphere.
+func (cds Polygon) Area() Km2 {
+	if len(cds.MultiPoint) == 0 {
+		return 0
+	}
+	return EarthAreaFromSteradian(cds.AreaAngle())
+}
+
+// AreaEllipsoid returns area excluding holes on ellipsoid e.
+// It is computed on the authalic sphere of the same area as e, where edges are great circles.
+// The difference from geodesic edges is negligible unless edges are very long.
+func (cds Polygon) AreaEllipsoid(e *Ellipsoid) Km2 {
+	if len(cds.MultiPoint) == 0 {
+		return 0
+	}
+	p := NewPolygon(e.authalicRing(cds.MultiPoint))
+	for _, h := range cds.Holes {
+		p.Holes = append(p.Holes, e.authalicRing(h.MultiPoint))
+	}
+	_, r := e.authalic(0)
+	return Km2(p.AreaAngle() * float64(r*r))
+}
+
+// Perimeter returns length of outer ring and holes on the sphere.
+func (cds Polygon) Perimeter() Km {
+	km := segmentLength(cds.MultiPoint, true, nil)
+	for _, h := range cds.Holes {
+		km += segmentLength(h.MultiPoint, true, nil)
+	}
+	return km
+}
+
+// PerimeterEllipsoid returns length of outer ring and holes by geodesics on ellipsoid e.
+func (cds Polygon) PerimeterEllipsoid(e *Ellipsoid) Km {
+	km := segmentLength(cds.MultiPoint, true, e)
+	for _, h := range cds.Holes {
+		km += segmentLength(h.MultiPoint, true, e)
+	}
+	return km
+}
+
+// Area returns sum of area of cds on the sphere.
+func (cds MultiPolygon) Area() (km2 Km2) {
+	for i := range cds {
+		km2 += cds[i].Area()
+	}
+	return
+}
+
+// AreaEllipsoid returns sum of area of cds on ellipsoid e.
+func (cds MultiPolygon) AreaEllipsoid(e *Ellipsoid) (km2 Km2) {
+	for i := range cds {
+		km2 += cds[i].AreaEllipsoid(e)
+	}
+	return
+}
+
+// Perimeter returns sum of perimeter of cds on the sphere.
+func (cds MultiPolygon) Perimeter() (km Km) {
+	for i := range cds {
+		km += cds[i].Perimeter()
+	}
+	return
+}
+
+// PerimeterEllipsoid returns sum of perimeter of cds on ellipsoid e.
+func (cds MultiPolygon) PerimeterEllipsoid(e *Ellipsoid) (km Km) {
+	for i := range cds {
+		km += cds[i].PerimeterEllipsoid(e)
+	}
+	return
+}
+
+// Area returns area of rect bounded by latitudes and longitudes on the sphere.
+func (rect *Rect) Area() Km2 {
+	return EarthAreaFromSteradian(rect.Rect.Area())
+}
+
+// AreaEllipsoid returns area of rect bounded by latitudes and longitudes on ellipsoid e.
+func (rect *Rect) AreaEllipsoid(e *Ellipsoid) Km2 {
+	if rect.IsEmpty() {
+		return 0
+	}
+	lo, _ := e.authalic(s1.Angle(rect.Lat.Lo))
+	hi, r := e.authalic(s1.Angle(rect.Lat.Hi))
+	return Km2(float64(r*r) * (math.Sin(float64(hi)) - math.Sin(float64(lo))) * rect.Lng.Length())
+}
+
+// Area returns area of c on the sphere.
+func (c Circle) Area() Km2 {
+	return EarthAreaFromSteradian(c.S2Cap().Area())
+}
+
+// AreaEllipsoid returns area within geodesic distance of radius of c from the center on ellipsoid e.
+// It is area of polygon of geodesic vertices, corrected by ratio of circle to polygon on the sphere.
+func (c Circle) AreaEllipsoid(e *Ellipsoid) Km2 {
+	const div = 360
+	km := c.Radius()
+	if km <= 0 {
+		return 0
+	}
+	cds := make(MultiPoint, div+1)
+	for i := 0; i < div; i++ {
+		cds[i], _ = c.Point.GeodesicDirect(s1.Angle(2*math.Pi*float64(div-i)/div), km, e)
+	}
+	cds[div] = cds[0]
+	// ratio of circle to polygon of same number of vertices on the sphere.
+	ratio := c.S2Cap().Area() / c.Polygon(div).AreaAngle()
+	return NewPolygon(LineString{MultiPoint: cds}).AreaEllipsoid(e) * Km2(ratio)
+}
+
+// Perimeter returns length of circumference of c on the sphere.
+func (c Circle) Perimeter() Km {
+	return Km(2 * math.Pi * radiusKmOfTheEarth * math.Sin(float64(c.Angle())))
+}
@@ -0,0 +1,79 @@
+package latlong_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/s2"
+	latlong "github.com/toyo/go-latlong"
+)
+
+func TestMeasure(t *testing.T) {
+	pt := func(lat, lng float64) latlong.Point {
+		return latlong.NewPoint(latlong.NewAngle(lat, 0), latlong.NewAngle(lng, 0), nil)
+	}
+	line := func(cds ...latlong.Point) latlong.LineString {
+		return latlong.LineString{MultiPoint: cds}
+	}
+	near := func(a, b, tolerance float64) bool {
+		return math.Abs(a-b) <= tolerance
+	}
+
+	l := line(pt(0, 0), pt(0, 0.5), pt(0, 1))
+	if km := l.Length(); !near(float64(km), 111.195, 0.001) || km.String() != "111.2km" {
+		t.Errorf("was %v", km)
+	}
+	if km := l.LengthEllipsoid(latlong.WGS84); !near(float64(km), 111.319, 0.001) {
+		t.Errorf("was %v", km)
+	}
+	if km := (latlong.MultiLineString{l, l}).Length(); !near(float64(km), 222.390, 0.001) {
+		t.Errorf("was %v", km)
+	}
+
+	rect := latlong.NewRect(0.5, 0.5, 1, 1)
+	if km2 := rect.Area(); !near(float64(km2), 12363.72, 0.01) || km2.String() != "12363.7km2" {
+		t.Errorf("was %v", km2)
+	}
+	if km2 := rect.AreaEllipsoid(latlong.WGS84); !near(float64(km2), 12308.46, 0.01) {
+		t.Errorf("was %v", km2)
+	}
+	full := &latlong.Rect{Rect: s2.FullRect()}
+	if km2 := full.AreaEllipsoid(latlong.WGS84); !near(float64(km2), 510065621.7, 0.1) {
+		t.Errorf("was %v", km2)
+	}
+
+	square := latlong.NewPolygon(line(pt(0, 0), pt(0, 1), pt(1, 1), pt(1, 0), pt(0, 0)))
+	if km2 := square.Area(); !near(float64(km2), float64(rect.Area()), 2) {
+		t.Errorf("was %v", km2)
+	}
+	if km2 := square.AreaEllipsoid(latlong.WGS84); !near(float64(km2), float64(rect.AreaEllipsoid(latlong.WGS84)), 2) {
+		t.Errorf("was %v", km2)
+	}
+	if km := square.Perimeter(); !near(float64(km), 4*111.195, 0.05) {
+		t.Errorf("was %v", km)
+	}
+	holed := latlong.NewPolygon(square.LineString, line(pt(0.25, 0.25), pt(0.25, 0.75), pt(0.75, 0.75), pt(0.75, 0.25)))
+	if km2 := holed.Area(); !near(float64(km2), float64(square.Area())*0.75, 1) {
+		t.Errorf("was %v", km2)
+	}
+	if km := holed.PerimeterEllipsoid(latlong.WGS84); !near(float64(km), float64(square.PerimeterEllipsoid(latlong.WGS84))*1.5, 0.5) {
+		t.Errorf("was %v", km)
+	}
+	if km2 := (latlong.MultiPolygon{square, holed}).Area(); !near(float64(km2), float64(square.Area())*1.75, 1) {
+		t.Errorf("was %v", km2)
+	}
+
+	c := latlong.NewCircle(pt(35, 135), 10)
+	if km2 := c.Area(); !near(float64(km2), math.Pi*100, 0.01) {
+		t.Errorf("was %v", km2)
+	}
+	if km := c.Perimeter(); !near(float64(km), 2*math.Pi*10, 0.01) {
+		t.Errorf("was %v", km)
+	}
+	if km2 := c.AreaEllipsoid(latlong.WGS84); !near(float64(km2), math.Pi*100, 0.01) {
+		t.Errorf("was %v", km2)
+	}
+	if km2 := latlong.NewCircle(pt(0, 0), 1000).AreaEllipsoid(latlong.WGS84); !near(float64(km2), 3135119.4, 1) {
+		t.Errorf("was %v", km2)
+	}
+}
@@ -0,0 +1,124 @@
+package latlong
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/golang/geo/r3"
+	"github.com/golang/geo/s2"
+)
+
+// MultiLineString is slice of LineString
+type MultiLineString []LineString
+
+// Type returns this type
+func (MultiLineString) Type() string {
+	return "MultiLineString"
+}
+
+// S2Region is getter for s2.Region.
+func (cds MultiLineString) S2Region() s2.Region {
+	ru := make(s2.RegionUnion, len(cds))
+	for i := range cds {
+		ru[i] = cds[i].S2Region()
+	}
+	return ru
+}
+
+// CapBound is for s2.Region interface.
+func (cds *MultiLineString) CapBound() s2.Cap {
+	return cds.S2Region().CapBound()
+}
+
+// RectBound is for s2.Region interface.
+func (cds *MultiLineString) RectBound() s2.Rect {
+	return cds.S2Region().RectBound()
+}
+
+// ContainsCell is for s2.Region interface.
+func (cds *MultiLineString) ContainsCell(c s2.Cell) bool {
+	return cds.S2Region().ContainsCell(c)
+}
+
+// IntersectsCell is for s2.Region interface.
+func (cds *MultiLineString) IntersectsCell(c s2.Cell) bool {
+	return cds.S2Region().IntersectsCell(c)
+}
+
+// ContainsPoint is for s2.Region interface.
+func (cds *MultiLineString) ContainsPoint(p s2.Point) bool {
+	return cds.S2Region().ContainsPoint(p)
+}
+
+// CellUnionBound is for s2.Region interface.
+func (cds *MultiLineString) CellUnionBound() []s2.CellID {
+	return cds.S2Region().CellUnionBound()
+}
+
+// S2Point is Center weighted by length.
+func (cds MultiLineString) S2Point() s2.Point {
+	var v r3.Vector
+	for i := range cds {
+		pl := cds[i].S2Polyline()
+		v = v.Add(pl.Centroid().Vector)
+	}
+	return s2.Point{Vector: v.Normalize()}
+}
+
+// Radiusp is un-used
+func (cds MultiLineString) Radiusp() *float64 {
+	return nil
+}
+
+func (cds MultiLineString) String() string {
+	var ss []string
+	for _, l := range cds {
+		ss = append(ss, l.String())
+	}
+	return strings.Join(ss, "/")
+}
+
+// Equal return bool
+func (cds MultiLineString) Equal(c1 Geometry) bool {
+	c, ok := c1.(MultiLineString)
+	if !ok || len(cds) != len(c) {
+		return false
+	}
+	for i := range cds {
+		if !cds[i].Equal(c[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalJSON is a marshaler for JSON.
+func (cds MultiLineString) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]LineString(cds))
+}
+
+// UnmarshalJSON is a unmarshaler for JSON.
+func (cds *MultiLineString) UnmarshalJSON(data []byte) error {
+	var v []LineString
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*cds = v
+	return nil
+}
+
+// NewGeoJSONGeometry returns GeoJSONGeometry.
+func (cds MultiLineString) NewGeoJSONGeometry() *GeoJSONGeometry {
+	var g GeoJSONGeometry
+	g.geo = cds
+	return &g
+}
+
+// NewGeoJSONFeature returns GeoJSONFeature.
+func (cds MultiLineString) NewGeoJSONFeature(property interface{}) *GeoJSONFeature {
+	var g GeoJSONFeature
+	g.Type = "Feature"
+	g.Geometry = cds.NewGeoJSONGeometry()
+	g.Property = property
+	return &g
+}
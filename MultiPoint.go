@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"strings"
+	"unicode"
 
 	"github.com/golang/geo/s2"
 )
@@ -29,26 +30,53 @@ func (cds MultiPoint) S2Point() s2.Point {
 	return cds.Point().S2Point()
 }
 
-// S2Region is nil
+// S2Region is getter for s2.Region.
 func (cds MultiPoint) S2Region() s2.Region {
-	return nil
+	ru := make(s2.RegionUnion, len(cds))
+	for i := range cds {
+		ru[i] = cds[i].S2Region()
+	}
+	return ru
 }
 
 // UnmarshalText is from ISO6709 latlongs.
+// Offset of *ISO6709Error is relative to str.
 func (cds *MultiPoint) UnmarshalText(str []byte) error {
+	offset := 0
 	for _, s := range bytes.Split(str, []byte(`/`)) {
-		if len(s) != 0 {
-			var p Point
-			err := p.UnmarshalText(s)
-			if err != nil {
-				return err
+		start := offset
+		offset += len(s) + 1
+		if len(bytes.TrimSpace(s)) == 0 {
+			continue
+		}
+		start += len(s) - len(bytes.TrimLeftFunc(s, unicode.IsSpace))
+
+		p, err := ParseISO6709(bytes.TrimSpace(s))
+		if err != nil {
+			if e, ok := err.(*ISO6709Error); ok {
+				e.Text = string(str)
+				e.Offset += start
 			}
-			*cds = append(*cds, p)
+			return err
 		}
+		*cds = append(*cds, p)
 	}
 	return nil
 }
 
+// MarshalText is to ISO6709 latlongs.
+func (cds MultiPoint) MarshalText() (b []byte, err error) {
+	for _, p := range cds {
+		b = appendISO6709(b, p)
+	}
+	return
+}
+
+// MarshalJSON is a marshaler for JSON.
+func (cds MultiPoint) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]Point(cds))
+}
+
 // UnmarshalJSON is from ISO6709 latlongs.
 func (cds *MultiPoint) UnmarshalJSON(str []byte) error {
 	var v []Point
@@ -104,12 +132,12 @@ func (cds MultiPoint) NewGeoJSONFeature(property interface{}) *GeoJSONFeature {
 
 // Equal return bool
 func (cds MultiPoint) Equal(c1 Geometry) bool {
-	c := c1.(MultiPoint)
-	if len(cds) != len(c) {
+	c, ok := c1.(MultiPoint)
+	if !ok || len(cds) != len(c) {
 		return false
 	}
 	for i := range cds {
-		if cds[i] != c[i] {
+		if !cds[i].Equal(c[i]) {
 			return false
 		}
 	}
@@ -0,0 +1,131 @@
+package latlong
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/golang/geo/r3"
+	"github.com/golang/geo/s2"
+)
+
+// MultiPolygon is slice of Polygon
+type MultiPolygon []Polygon
+
+// Type returns this type
+func (MultiPolygon) Type() string {
+	return "MultiPolygon"
+}
+
+// S2Region is getter for s2.Region.
+func (cds MultiPolygon) S2Region() s2.Region {
+	ru := make(s2.RegionUnion, len(cds))
+	for i := range cds {
+		ru[i] = cds[i].S2Polygon()
+	}
+	return ru
+}
+
+// CapBound is for s2.Region interface.
+func (cds *MultiPolygon) CapBound() s2.Cap {
+	return cds.S2Region().CapBound()
+}
+
+// RectBound is for s2.Region interface.
+func (cds *MultiPolygon) RectBound() s2.Rect {
+	return cds.S2Region().RectBound()
+}
+
+// ContainsCell is for s2.Region interface.
+func (cds *MultiPolygon) ContainsCell(c s2.Cell) bool {
+	return cds.S2Region().ContainsCell(c)
+}
+
+// IntersectsCell is for s2.Region interface.
+func (cds *MultiPolygon) IntersectsCell(c s2.Cell) bool {
+	return cds.S2Region().IntersectsCell(c)
+}
+
+// ContainsPoint is for s2.Region interface.
+func (cds *MultiPolygon) ContainsPoint(p s2.Point) bool {
+	return cds.S2Region().ContainsPoint(p)
+}
+
+// CellUnionBound is for s2.Region interface.
+func (cds *MultiPolygon) CellUnionBound() []s2.CellID {
+	return cds.S2Region().CellUnionBound()
+}
+
+// S2Point is Center weighted by area.
+func (cds MultiPolygon) S2Point() s2.Point {
+	var v r3.Vector
+	for i := range cds {
+		v = v.Add(cds[i].centroidVector())
+	}
+	return s2.Point{Vector: v.Normalize()}
+}
+
+// AreaAngle returns sum of area in steradian.
+func (cds MultiPolygon) AreaAngle() (area float64) {
+	for i := range cds {
+		area += cds[i].AreaAngle()
+	}
+	return
+}
+
+// Radiusp is un-used
+func (cds MultiPolygon) Radiusp() *float64 {
+	return nil
+}
+
+func (cds MultiPolygon) String() string {
+	var ss []string
+	for _, p := range cds {
+		ss = append(ss, p.String())
+	}
+	return strings.Join(ss, "/")
+}
+
+// Equal return bool
+func (cds MultiPolygon) Equal(c1 Geometry) bool {
+	c, ok := c1.(MultiPolygon)
+	if !ok || len(cds) != len(c) {
+		return false
+	}
+	for i := range cds {
+		if !cds[i].Equal(c[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalJSON is a marshaler for JSON.
+func (cds MultiPolygon) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]Polygon(cds))
+}
+
+// UnmarshalJSON is a unmarshaler for JSON.
+func (cds *MultiPolygon) UnmarshalJSON(data []byte) error {
+	var v []Polygon
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*cds = v
+	return nil
+}
+
+// NewGeoJSONGeometry returns GeoJSONGeometry.
+func (cds MultiPolygon) NewGeoJSONGeometry() *GeoJSONGeometry {
+	var g GeoJSONGeometry
+	g.geo = cds
+	return &g
+}
+
+// NewGeoJSONFeature returns GeoJSONFeature.
+func (cds MultiPolygon) NewGeoJSONFeature(property interface{}) *GeoJSONFeature {
+	var g GeoJSONFeature
+	g.Type = "Feature"
+	g.Geometry = cds.NewGeoJSONGeometry()
+	g.Property = property
+	return &g
+}
@@ -0,0 +1,160 @@
+package latlong
+
+import (
+	"math"
+
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+)
+
+// navPoint returns Point of lat and lng in radian, which precision and datum are inherited from latlong.
+func (latlong Point) navPoint(lat, lng float64) Point {
+	p := NewPoint(
+		NewAngleFromS1Angle(s1.Angle(lat), latlong.lat.radianprec),
+		NewAngleFromS1Angle(s1.Angle(math.Remainder(lng, 2*math.Pi)), latlong.lng.radianprec),
+		nil)
+	p.datum = latlong.datum
+	return p
+}
+
+// navBearing normalizes radian to bearing in [0, 360) degrees.
+func navBearing(radian float64) s1.Angle {
+	return s1.Angle(math.Mod(radian+2*math.Pi, 2*math.Pi))
+}
+
+// InitialBearing returns bearing at latlong of great circle to latlong1, clockwise from north in [0, 360) degrees.
+func (latlong Point) InitialBearing(latlong1 *Point) s1.Angle {
+	lat1, lat2 := float64(latlong.lat.radian), float64(latlong1.lat.radian)
+	dlng := float64(latlong1.lng.radian - latlong.lng.radian)
+	return navBearing(math.Atan2(math.Sin(dlng)*math.Cos(lat2),
+		math.Cos(lat1)*math.Sin(lat2)-math.Sin(lat1)*math.Cos(lat2)*math.Cos(dlng)))
+}
+
+// FinalBearing returns bearing at latlong1 of great circle from latlong.
+func (latlong Point) FinalBearing(latlong1 *Point) s1.Angle {
+	return navBearing(float64(latlong1.InitialBearing(&latlong) + math.Pi))
+}
+
+// Destination returns the point at km along great circle from latlong toward bearing.
+// Precision and datum are inherited from latlong.
+func (latlong Point) Destination(bearing s1.Angle, km Km) Point {
+	lat1, lng1 := float64(latlong.lat.radian), float64(latlong.lng.radian)
+	d, b := float64(km.EarthAngle()), float64(bearing)
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(d) + math.Cos(lat1)*math.Sin(d)*math.Cos(b))
+	lng2 := lng1 + math.Atan2(math.Sin(b)*math.Sin(d)*math.Cos(lat1), math.Cos(d)-math.Sin(lat1)*math.Sin(lat2))
+	return latlong.navPoint(lat2, lng2)
+}
+
+// IntermediatePoint returns the point at fraction between latlong (0) and latlong1 (1) along great circle.
+func (latlong Point) IntermediatePoint(latlong1 *Point, fraction float64) Point {
+	ll := s2.LatLngFromPoint(s2.Interpolate(fraction, latlong.S2Point(), latlong1.S2Point()))
+	return latlong.navPoint(float64(ll.Lat), float64(ll.Lng))
+}
+
+// Midpoint returns the midpoint of great circle between latlong and latlong1.
+func (latlong Point) Midpoint(latlong1 *Point) Point {
+	return latlong.IntermediatePoint(latlong1, 0.5)
+}
+
+// CrossTrackDistance returns distance of latlong from great circle through start and end.
+// It is positive if latlong is on the right, and negative if on the left.
+func (latlong Point) CrossTrackDistance(start, end *Point) Km {
+	d13 := float64(start.DistanceAngle(&latlong))
+	b13, b12 := float64(start.InitialBearing(&latlong)), float64(start.InitialBearing(end))
+	return EarthArcFromAngle(s1.Angle(math.Asin(math.Sin(d13) * math.Sin(b13-b12))))
+}
+
+// AlongTrackDistance returns distance from start to the closest point to latlong on great circle
+// through start and end. It is negative if the closest point is behind start.
+func (latlong Point) AlongTrackDistance(start, end *Point) Km {
+	d13 := float64(start.DistanceAngle(&latlong))
+	b13, b12 := float64(start.InitialBearing(&latlong)), float64(start.InitialBearing(end))
+	dxt := math.Asin(math.Sin(d13) * math.Sin(b13-b12))
+	dat := math.Acos(math.Max(-1, math.Min(1, math.Cos(d13)/math.Cos(dxt))))
+	if math.Cos(b12-b13) < 0 {
+		dat = -dat
+	}
+	return EarthArcFromAngle(s1.Angle(dat))
+}
+
+// rhumb returns difference of latitude, projected latitude and longitude between latlong and latlong1,
+// and the ratio of latitude to projected latitude. dlng is the shorter way.
+func (latlong Point) rhumb(latlong1 *Point) (dlat, dpsi, dlng, q float64) {
+	lat1, lat2 := float64(latlong.lat.radian), float64(latlong1.lat.radian)
+	dlat = lat2 - lat1
+	dpsi = math.Log(math.Tan(math.Pi/4+lat2/2) / math.Tan(math.Pi/4+lat1/2))
+	dlng = math.Remainder(float64(latlong1.lng.radian-latlong.lng.radian), 2*math.Pi)
+	q = math.Cos(lat1)
+	if math.Abs(dpsi) > 1e-12 {
+		q = dlat / dpsi
+	}
+	return
+}
+
+// RhumbBearing returns constant bearing of rhumb line from latlong to latlong1.
+func (latlong Point) RhumbBearing(latlong1 *Point) s1.Angle {
+	_, dpsi, dlng, _ := latlong.rhumb(latlong1)
+	return navBearing(math.Atan2(dlng, dpsi))
+}
+
+// RhumbDistanceKm returns distance along rhumb line from latlong to latlong1.
+func (latlong Point) RhumbDistanceKm(latlong1 *Point) Km {
+	dlat, _, dlng, q := latlong.rhumb(latlong1)
+	return EarthArcFromAngle(s1.Angle(math.Hypot(dlat, q*dlng)))
+}
+
+// RhumbDestination returns the point at km along rhumb line from latlong toward bearing.
+// Precision and datum are inherited from latlong.
+func (latlong Point) RhumbDestination(bearing s1.Angle, km Km) Point {
+	lat1, lng1 := float64(latlong.lat.radian), float64(latlong.lng.radian)
+	d, b := float64(km.EarthAngle()), float64(bearing)
+	lat2 := lat1 + d*math.Cos(b)
+	if math.Abs(lat2) > math.Pi/2 { // beyond the pole.
+		lat2 = math.Copysign(math.Pi, lat2) - lat2
+	}
+	dpsi := math.Log(math.Tan(math.Pi/4+lat2/2) / math.Tan(math.Pi/4+lat1/2))
+	q := math.Cos(lat1)
+	if math.Abs(dpsi) > 1e-12 {
+		q = (lat2 - lat1) / dpsi
+	}
+	return latlong.navPoint(lat2, lng1+d*math.Sin(b)/q)
+}
+
+// RhumbMidpoint returns the midpoint of rhumb line between latlong and latlong1.
+func (latlong Point) RhumbMidpoint(latlong1 *Point) Point {
+	lat1, lat2 := float64(latlong.lat.radian), float64(latlong1.lat.radian)
+	lng1 := float64(latlong.lng.radian)
+	_, _, dlng, _ := latlong.rhumb(latlong1)
+	lng2 := lng1 + dlng
+	latm := (lat1 + lat2) / 2
+	f1, f2, fm := math.Tan(math.Pi/4+lat1/2), math.Tan(math.Pi/4+lat2/2), math.Tan(math.Pi/4+latm/2)
+	lngm := (lng1 + lng2) / 2
+	if math.Abs(lat2-lat1) > 1e-12 {
+		lngm = ((lng2-lng1)*math.Log(fm) + lng1*math.Log(f2) - lng2*math.Log(f1)) / math.Log(f2/f1)
+	}
+	return latlong.navPoint(latm, lngm)
+}
+
+// TrackDistance returns index of the segment of cds nearest to latlong, and cross-track and along-track
+// distance of latlong from the segment. alongTrack is from the start of cds through the previous segments.
+// segment is -1 if cds has no segment of length.
+func (cds LineString) TrackDistance(latlong Point) (segment int, crossTrack, alongTrack Km) {
+	segment = -1
+	p := latlong.S2Point()
+	d := s1.InfAngle()
+	for i := 0; i+1 < len(cds.MultiPoint); i++ {
+		a, b := cds.MultiPoint[i].S2Point(), cds.MultiPoint[i+1].S2Point()
+		if a.Distance(b) <= pointTolerance {
+			continue
+		}
+		if dd := s2.DistanceFromSegment(p, a, b); dd < d {
+			segment, d = i, dd
+		}
+	}
+	if segment < 0 {
+		return
+	}
+	start, end := &cds.MultiPoint[segment], &cds.MultiPoint[segment+1]
+	return segment, latlong.CrossTrackDistance(start, end),
+		LineString{MultiPoint: cds.MultiPoint[:segment+1]}.Length() + latlong.AlongTrackDistance(start, end)
+}
@@ -0,0 +1,100 @@
+package latlong_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/s1"
+	latlong "github.com/toyo/go-latlong"
+)
+
+func TestNavigation(t *testing.T) {
+	pt := func(lat, lng float64) latlong.Point {
+		return latlong.NewPoint(latlong.NewAngle(lat, 0), latlong.NewAngle(lng, 0), nil)
+	}
+	near := func(p latlong.Point, lat, lng, tolerance float64) bool {
+		return math.Abs(p.Lat().Degrees()-lat) <= tolerance && math.Abs(p.Lng().Degrees()-lng) <= tolerance
+	}
+
+	cambridge, paris := pt(52.205, 0.119), pt(48.857, 2.351)
+	if b := cambridge.InitialBearing(&paris).Degrees(); math.Abs(b-156.2) > 0.05 {
+		t.Errorf("was %v", b)
+	}
+	if b := cambridge.FinalBearing(&paris).Degrees(); math.Abs(b-157.9) > 0.05 {
+		t.Errorf("was %v", b)
+	}
+	if b := paris.InitialBearing(&cambridge).Degrees(); math.Abs(b-337.9) > 0.05 {
+		t.Errorf("was %v", b)
+	}
+	if m := cambridge.Midpoint(&paris); !near(m, 50.5363, 1.2746, 1e-4) {
+		t.Errorf("was %v", m)
+	}
+	if m := cambridge.IntermediatePoint(&paris, 1); !near(m, 48.857, 2.351, 1e-9) {
+		t.Errorf("was %v", m)
+	}
+
+	start := pt(53.3206, -1.7297)
+	if d := start.Destination(96.0217*s1.Degree, 124.8); !near(d, 53.1883, 0.1333, 1e-4) {
+		t.Errorf("was %v", d)
+	}
+	here := pt(35.681, 139.767)
+	dest := here.Destination(45*s1.Degree, 12)
+	if d := here.DistanceEarthKm(&dest); math.Abs(float64(d)-12) > 1e-9 {
+		t.Errorf("was %v", d)
+	}
+	if b := here.InitialBearing(&dest).Degrees(); math.Abs(b-45) > 1e-9 {
+		t.Errorf("was %v", b)
+	}
+	if d := pt(0, 179.5).Destination(90*s1.Degree, latlong.EarthArcFromAngle(s1.Degree)); !near(d, 0, -179.5, 1e-9) {
+		t.Errorf("was %v", d)
+	}
+
+	p, end := pt(53.2611, -0.7972), pt(53.1887, 0.1334)
+	if d := p.CrossTrackDistance(&start, &end); math.Abs(float64(d)+0.3075) > 0.001 {
+		t.Errorf("was %v", float64(d))
+	}
+	if d := p.AlongTrackDistance(&start, &end); math.Abs(float64(d)-62.331) > 0.01 {
+		t.Errorf("was %v", d)
+	}
+	if d := p.AlongTrackDistance(&end, &start); math.Abs(float64(d)-(float64(start.DistanceEarthKm(&end))-62.331)) > 0.01 {
+		t.Errorf("was %v", d)
+	}
+	if d := start.AlongTrackDistance(&p, &end); d >= 0 {
+		t.Errorf("was %v", d)
+	}
+
+	route := latlong.LineString{MultiPoint: latlong.MultiPoint{pt(53.3206, -2), start, end}}
+	if i, xt, at := route.TrackDistance(p); i != 1 || math.Abs(float64(xt)+0.3075) > 0.001 ||
+		math.Abs(float64(at-pt(53.3206, -2).DistanceEarthKm(&start))-62.331) > 0.01 {
+		t.Errorf("was %d %v %v", i, xt, at)
+	}
+	if i, _, _ := (latlong.LineString{MultiPoint: latlong.MultiPoint{start, start}}).TrackDistance(p); i != -1 {
+		t.Errorf("was %d", i)
+	}
+
+	dover, calais := pt(51.127, 1.338), pt(50.964, 1.853)
+	if d := dover.RhumbDistanceKm(&calais); math.Abs(float64(d)-40.31) > 0.01 {
+		t.Errorf("was %v", d)
+	}
+	b := dover.RhumbBearing(&calais)
+	if math.Abs(b.Degrees()-116.7) > 0.05 {
+		t.Errorf("was %v", b.Degrees())
+	}
+	if d := dover.RhumbDestination(b, dover.RhumbDistanceKm(&calais)); !near(d, 50.964, 1.853, 1e-9) {
+		t.Errorf("was %v", d)
+	}
+	if m := dover.RhumbMidpoint(&calais); !near(m, 51.0455, 1.5957, 1e-4) {
+		t.Errorf("was %v", m)
+	}
+	// across the antimeridian.
+	w, e := pt(10, 179), pt(10, -179)
+	if b := w.RhumbBearing(&e).Degrees(); math.Abs(b-90) > 1e-9 {
+		t.Errorf("was %v", b)
+	}
+	if d := w.RhumbDestination(90*s1.Degree, w.RhumbDistanceKm(&e)); !near(d, 10, -179, 1e-9) {
+		t.Errorf("was %v", d)
+	}
+	if m := w.RhumbMidpoint(&e); math.Abs(m.Lat().Degrees()-10) > 1e-9 || math.Abs(math.Abs(m.Lng().Degrees())-180) > 1e-9 {
+		t.Errorf("was %v", m)
+	}
+}
@@ -0,0 +1,270 @@
+package latlong
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/golang/geo/s1"
+)
+
+// PlaneRectJP is a coordinate of Japan Plane Rectangular Coordinate System (平面直角座標系)
+// on JGD2011 (EPSG:6669 to 6687).
+type PlaneRectJP struct {
+	Zone int     // 1 to 19
+	X    float64 // northing in meters from the origin of the zone.
+	Y    float64 // easting in meters from the origin of the zone.
+}
+
+// planeRectJPOrigins are origins of zones I to XIX in degrees and minutes.
+var planeRectJPOrigins = [...]struct{ latdeg, lngdeg, lngmin float64 }{
+	{33, 129, 30}, {33, 131, 0}, {36, 132, 10}, {33, 133, 30}, {36, 134, 20},
+	{36, 136, 0}, {36, 137, 10}, {36, 138, 30}, {36, 139, 50}, {40, 140, 50},
+	{44, 140, 15}, {44, 142, 15}, {44, 144, 15}, {26, 142, 0}, {26, 127, 30},
+	{26, 124, 0}, {26, 131, 0}, {20, 136, 0}, {26, 154, 0},
+}
+
+const planeRectJPScale = 0.9999 // scale factor on the central meridian.
+
+// EPSG returns EPSG code of the zone.
+func (xy PlaneRectJP) EPSG() int {
+	return 6668 + xy.Zone
+}
+
+// origin returns origin of zone in radian.
+func (xy PlaneRectJP) origin() (lat0, lng0 float64, err error) {
+	if xy.Zone < 1 || xy.Zone > len(planeRectJPOrigins) {
+		return 0, 0, errors.New("Plane rectangular zone error " + strconv.Itoa(xy.Zone))
+	}
+	o := planeRectJPOrigins[xy.Zone-1]
+	return o.latdeg * math.Pi / 180, (o.lngdeg + o.lngmin/60) * math.Pi / 180, nil
+}
+
+// gaussKruger has coefficients of Gauss-Krüger projection by Krüger series.
+// K. Kawase, A General Formula for Calculating Meridian Arc Length and its Application
+// to Coordinate Conversion in the Gauss-Krüger Projection (2011).
+type gaussKruger struct {
+	a, n       float64
+	abar       float64    // Ā
+	ac         [6]float64 // A0 to A5
+	alpha      [6]float64 // α1 to α5 (index 0 unused)
+	beta       [6]float64 // β1 to β5
+	delta      [7]float64 // δ1 to δ6
+	nratiotanp float64    // (1-n)/(1+n)
+}
+
+func newGaussKruger(e *Ellipsoid, m0 float64) (g gaussKruger) {
+	n := e.n
+	n2, n3, n4, n5, n6 := n*n, n*n*n, n*n*n*n, n*n*n*n*n, n*n*n*n*n*n
+	g.a, g.n = e.a, n
+	g.ac = [6]float64{
+		1 + n2/4 + n4/64,
+		-3.0 / 2 * (n - n3/8 - n5/64),
+		15.0 / 16 * (n2 - n4/4),
+		-35.0 / 48 * (n3 - 5.0/16*n5),
+		315.0 / 512 * n4,
+		-693.0 / 1280 * n5,
+	}
+	g.abar = m0 * e.a / (1 + n) * g.ac[0]
+	g.alpha = [6]float64{0,
+		n/2 - 2.0/3*n2 + 5.0/16*n3 + 41.0/180*n4 - 127.0/288*n5,
+		13.0/48*n2 - 3.0/5*n3 + 557.0/1440*n4 + 281.0/630*n5,
+		61.0/240*n3 - 103.0/140*n4 + 15061.0/26880*n5,
+		49561.0/161280*n4 - 179.0/168*n5,
+		34729.0 / 80640 * n5,
+	}
+	g.beta = [6]float64{0,
+		n/2 - 2.0/3*n2 + 37.0/96*n3 - 1.0/360*n4 - 81.0/512*n5,
+		1.0/48*n2 + 1.0/15*n3 - 437.0/1440*n4 + 46.0/105*n5,
+		17.0/480*n3 - 37.0/840*n4 - 209.0/4480*n5,
+		4397.0/161280*n4 - 11.0/504*n5,
+		4583.0 / 161280 * n5,
+	}
+	g.delta = [7]float64{0,
+		2*n - 2.0/3*n2 - 2*n3 + 116.0/45*n4 + 26.0/45*n5 - 2854.0/675*n6,
+		7.0/3*n2 - 8.0/5*n3 - 227.0/45*n4 + 2704.0/315*n5 + 2323.0/945*n6,
+		56.0/15*n3 - 136.0/35*n4 - 1262.0/105*n5 + 73814.0/2835*n6,
+		4279.0/630*n4 - 332.0/35*n5 - 399572.0/14175*n6,
+		4174.0/315*n5 - 144838.0/6237*n6,
+		601676.0 / 22275 * n6,
+	}
+	g.nratiotanp = (1 - n) / (1 + n)
+	return
+}
+
+// meridianArc returns S̄φ0, scaled meridian arc length from the equator.
+func (g gaussKruger) meridianArc(lat0 float64) float64 {
+	s := g.ac[0] * lat0
+	for j := 1; j <= 5; j++ {
+		s += g.ac[j] * math.Sin(2*float64(j)*lat0)
+	}
+	return g.abar / g.ac[0] * s
+}
+
+// forward returns x, y in meters, meridian convergence in radian and scale factor.
+func (g gaussKruger) forward(lat, lng, lat0, lng0 float64) (x, y, gamma, m float64) {
+	sqn := 2 * math.Sqrt(g.n) / (1 + g.n)
+	t := math.Sinh(math.Atanh(math.Sin(lat)) - sqn*math.Atanh(sqn*math.Sin(lat)))
+	tbar := math.Sqrt(1 + t*t)
+	lc, ls := math.Cos(lng-lng0), math.Sin(lng-lng0)
+	xi := math.Atan2(t, lc)
+	eta := math.Atanh(ls / tbar)
+
+	x, y = xi, eta
+	sigma, tau := 1.0, 0.0
+	for j := 1; j <= 5; j++ {
+		j2 := 2 * float64(j)
+		s, c := math.Sincos(j2 * xi)
+		ch, sh := math.Cosh(j2*eta), math.Sinh(j2*eta)
+		x += g.alpha[j] * s * ch
+		y += g.alpha[j] * c * sh
+		sigma += j2 * g.alpha[j] * c * ch
+		tau += j2 * g.alpha[j] * s * sh
+	}
+	x = g.abar*x - g.meridianArc(lat0)
+	y = g.abar * y
+
+	gamma = math.Atan2(tau*tbar*lc+sigma*t*ls, sigma*tbar*lc-tau*t*ls)
+	m = g.abar / g.a * math.Sqrt((sigma*sigma+tau*tau)/(t*t+lc*lc)) *
+		math.Sqrt(1+math.Pow(g.nratiotanp*math.Tan(lat), 2))
+	return
+}
+
+// inverse returns latitude and longitude in radian, meridian convergence in radian and scale factor.
+func (g gaussKruger) inverse(x, y, lat0, lng0 float64) (lat, lng, gamma, m float64) {
+	xi := (x + g.meridianArc(lat0)) / g.abar
+	eta := y / g.abar
+
+	xi1, eta1 := xi, eta
+	sigma, tau := 1.0, 0.0
+	for j := 1; j <= 5; j++ {
+		j2 := 2 * float64(j)
+		s, c := math.Sincos(j2 * xi)
+		ch, sh := math.Cosh(j2*eta), math.Sinh(j2*eta)
+		xi1 -= g.beta[j] * s * ch
+		eta1 -= g.beta[j] * c * sh
+		sigma -= j2 * g.beta[j] * c * ch
+		tau += j2 * g.beta[j] * s * sh
+	}
+
+	chi := math.Asin(math.Sin(xi1) / math.Cosh(eta1))
+	lat = chi
+	for j := 1; j <= 6; j++ {
+		lat += g.delta[j] * math.Sin(2*float64(j)*chi)
+	}
+	lng = lng0 + math.Atan2(math.Sinh(eta1), math.Cos(xi1))
+
+	tt := math.Tan(xi1) * math.Tanh(eta1)
+	gamma = math.Atan2(tau+sigma*tt, sigma-tau*tt)
+	m = g.abar / g.a * math.Sqrt((math.Pow(math.Cos(xi1), 2)+math.Pow(math.Sinh(eta1), 2))/(sigma*sigma+tau*tau)) *
+		math.Sqrt(1+math.Pow(g.nratiotanp*math.Tan(lat), 2))
+	return
+}
+
+var planeRectJPGaussKruger = newGaussKruger(GRS80, planeRectJPScale)
+
+// PlaneRectJP converts latlong on JGD2011 to plane rectangular coordinate of zone.
+// gamma is meridian convergence (真北方向角 has opposite sign) and m is scale factor at latlong.
+func (latlong Point) PlaneRectJP(zone int) (xy PlaneRectJP, gamma s1.Angle, m float64, err error) {
+	xy.Zone = zone
+	lat0, lng0, err := xy.origin()
+	if err != nil {
+		return
+	}
+	var g float64
+	xy.X, xy.Y, g, m = planeRectJPGaussKruger.forward(float64(latlong.Lat().S1Angle()), float64(latlong.Lng().S1Angle()), lat0, lng0)
+	gamma = s1.Angle(g)
+	return
+}
+
+// Point converts plane rectangular coordinate to Point on JGD2011.
+// gamma is meridian convergence and m is scale factor at xy.
+func (xy PlaneRectJP) Point() (latlong Point, gamma s1.Angle, m float64, err error) {
+	lat0, lng0, err := xy.origin()
+	if err != nil {
+		return
+	}
+	lat, lng, g, m := planeRectJPGaussKruger.inverse(xy.X, xy.Y, lat0, lng0)
+	// precision of 1mm.
+	latlong = NewPoint(
+		NewAngleFromS1Angle(s1.Angle(lat), s1.Angle(0.001/GRS80.a)),
+		NewAngleFromS1Angle(s1.Angle(lng), s1.Angle(0.001/GRS80.a/math.Cos(lat))),
+		nil)
+	latlong.datum = DatumJGD2011
+	return latlong, s1.Angle(g), m, nil
+}
+
+// PlaneRectZoneJPByPref returns zone of plane rectangular coordinate system
+// from prefecture code (JIS X 0401) and latlong.
+// For Hokkaido, borders of subprefectures are approximated by longitude.
+func PlaneRectZoneJPByPref(prefcode int, latlong Point) (zone int, err error) {
+	lat, lng := latlong.Lat().Degrees(), latlong.Lng().Degrees()
+	switch prefcode {
+	case 1: // 北海道
+		if lng < 141.25 {
+			return 11, nil
+		} else if lng < 142.75 {
+			return 12, nil
+		}
+		return 13, nil
+	case 2, 3, 4, 5, 6:
+		return 10, nil
+	case 13: // 東京都
+		if lng > 150 {
+			return 19, nil // 南鳥島
+		} else if lat < 21 {
+			return 18, nil // 沖ノ鳥島
+		} else if lat < 28 {
+			return 14, nil // 小笠原
+		}
+		return 9, nil
+	case 7, 8, 9, 10, 11, 12, 14:
+		return 9, nil
+	case 15, 19, 20, 22:
+		return 8, nil
+	case 16, 17, 21, 23:
+		return 7, nil
+	case 18, 24, 25, 26, 27, 29, 30:
+		return 6, nil
+	case 28, 31, 33:
+		return 5, nil
+	case 36, 37, 38, 39:
+		return 4, nil
+	case 32, 34, 35:
+		return 3, nil
+	case 46: // 鹿児島県
+		if 27 <= lat && lat <= 32 && (lng <= 130 || (lat < 29 && lng <= 130+13.0/60)) {
+			return 1, nil
+		}
+		return 2, nil
+	case 40, 41, 43, 44, 45:
+		return 2, nil
+	case 42:
+		return 1, nil
+	case 47: // 沖縄県
+		if lng < 126 {
+			return 16, nil
+		} else if lng > 130 {
+			return 17, nil
+		}
+		return 15, nil
+	}
+	return 0, errors.New("Unknown prefecture code " + strconv.Itoa(prefcode))
+}
+
+// PlaneRectZoneJP returns zone of plane rectangular coordinate system
+// suggested from the prefecture of latlong by CityCodeJP.
+func (latlong *Point) PlaneRectZoneJP() (zone int, err error) {
+	code, err := latlong.CityCodeJP()
+	if err != nil {
+		return
+	}
+	if len(code) < 2 {
+		return 0, errors.New("No city code")
+	}
+	pref, err := strconv.Atoi(code[:2])
+	if err != nil {
+		return
+	}
+	return PlaneRectZoneJPByPref(pref, *latlong)
+}
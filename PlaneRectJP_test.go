@@ -0,0 +1,85 @@
+package latlong_test
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	latlong "github.com/toyo/go-latlong"
+)
+
+func TestPlaneRectJP(t *testing.T) {
+	// origin of zone IX.
+	origin := latlong.NewPoint(latlong.NewAngle(36, 0), latlong.NewAngle(139+50.0/60, 0), nil)
+	xy, gamma, m, err := origin.PlaneRectJP(9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(xy.X) > 1e-6 || math.Abs(xy.Y) > 1e-6 || math.Abs(gamma.Degrees()) > 1e-12 || math.Abs(m-0.9999) > 1e-12 {
+		t.Errorf("origin %#v %v %v", xy, gamma, m)
+	}
+	if xy.EPSG() != 6677 {
+		t.Errorf("EPSG %d", xy.EPSG())
+	}
+
+	// X on the central meridian is meridian arc multiplied by 0.9999.
+	north := latlong.NewPoint(latlong.NewAngle(37, 0), latlong.NewAngle(139+50.0/60, 0), nil)
+	xy, _, _, _ = north.PlaneRectJP(9)
+	arc, _, _ := origin.GeodesicInverse(&north, latlong.GRS80)
+	if expct := float64(arc) * 1000 * 0.9999; math.Abs(xy.X-expct) > 1e-3 {
+		t.Errorf("X expected %v, was %v", expct, xy.X)
+	}
+
+	p := latlong.NewPoint(latlong.NewAngle(35.681236, 0), latlong.NewAngle(140.9, 0), nil)
+	xy, gamma, m, err = p.PlaneRectJP(9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if xy.Y < 90000 || xy.Y > 100000 || gamma <= 0 {
+		t.Errorf("east of origin %#v %v", xy, gamma.Degrees())
+	}
+	if expct := 0.9999 * (1 + xy.Y*xy.Y/2/6371000/6371000); math.Abs(m-expct) > 1e-6 {
+		t.Errorf("scale expected %v, was %v", expct, m)
+	}
+
+	p1, gamma1, m1, err := xy.Point()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := p.DistanceEarthKm(&p1); d > 1e-6 {
+		t.Errorf("round trip expected %v, was %v", p, p1)
+	}
+	if math.Abs(float64(gamma-gamma1)) > 1e-12 || math.Abs(m-m1) > 1e-12 {
+		t.Errorf("convergence %v %v scale %v %v", gamma, gamma1, m, m1)
+	}
+
+	if _, _, _, err := p.PlaneRectJP(20); err == nil {
+		t.Error("expected zone error")
+	}
+}
+
+func TestPlaneRectZoneJP(t *testing.T) {
+	response := `{"ResultInfo":{"Count":1,"Status":200},"Feature":[{"Property":{"AddressElement":[{"Name":"","Level":"prefecture","Code":"24"},{"Name":"","Level":"city","Code":"24203"}]}}]}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, response)
+	}))
+	defer server.Close()
+	latlong.Config.YahooJPAPIURL = server.URL
+
+	p := latlong.NewPoint(latlong.NewAngle(34.455846, 0), latlong.NewAngle(136.725739, 0), nil)
+	zone, err := p.PlaneRectZoneJP()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if zone != 6 {
+		t.Errorf("expected 6, was %d", zone)
+	}
+
+	chichijima := latlong.NewPoint(latlong.NewAngle(27.09, 0), latlong.NewAngle(142.19, 0), nil)
+	if zone, _ := latlong.PlaneRectZoneJPByPref(13, chichijima); zone != 14 {
+		t.Errorf("expected 14, was %d", zone)
+	}
+}
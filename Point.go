@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"regexp"
 	"strconv"
 	"strings"
 
@@ -17,9 +16,10 @@ import (
 
 // Point is Latitude & Longitude with precision.
 type Point struct {
-	lat Angle
-	lng Angle
-	alt *float64 // altitude
+	lat   Angle
+	lng   Angle
+	alt   *float64 // altitude
+	datum Datum    // DatumUnknown if not specified
 }
 
 // Type returns this type
@@ -36,33 +36,19 @@ func NewPoint(lat, lng Angle, altitude *float64) (latlongalt Point) {
 }
 
 // UnmarshalText is from ISO6709 latlongs.
-func (latlong *Point) UnmarshalText(iso6709 []byte) error {
-	re := regexp.MustCompile(`(?P<Latitude>[\+-][\d.]+)(?P<Longitude>[\+-][\d.]+)(?P<Altitude>[\+-][\d.]+)?`)
-
-	if re.Match(iso6709) {
-		match := re.FindSubmatch(iso6709)
-
-		var lat, lng Angle
-		var altitude *float64
-
-		for i, name := range re.SubexpNames() {
-			if i == 0 || name == "" {
-				continue
-			}
-
-			switch name {
-			case "Latitude":
-				lat = AngleFromBytes(match[i])
-			case "Longitude":
-				lng = AngleFromBytes(match[i])
-			case "Altitude":
-				altitude = getAlt(match[i])
-			}
-		}
-		*latlong = NewPoint(lat, lng, altitude)
-		return nil
+// err is *ISO6709Error if iso6709 is malformed.
+func (latlong *Point) UnmarshalText(iso6709 []byte) (err error) {
+	var p Point
+	if p, err = ParseISO6709(bytes.TrimSpace(iso6709)); err == nil {
+		*latlong = p
 	}
-	panic(iso6709)
+	return
+}
+
+// MarshalText is to ISO6709 latlongs.
+// The form (Deg., DM or DMS) and digits are chosen from the precision.
+func (latlong Point) MarshalText() ([]byte, error) {
+	return appendISO6709(nil, latlong), nil
 }
 
 /*
@@ -112,9 +98,21 @@ func (latlong Point) Lng() Angle {
 	return latlong.lng
 }
 
+// Alt is getter for altitude. nil if unknown.
+func (latlong Point) Alt() *float64 {
+	return latlong.alt
+}
+
 // Equal is true if coordinate is same.
 func (latlong Point) Equal(latlong1 Geometry) bool {
-	return latlong == latlong1.(Point)
+	p, ok := latlong1.(Point)
+	if !ok || latlong.lat != p.lat || latlong.lng != p.lng || latlong.datum != p.datum {
+		return false
+	}
+	if latlong.alt == nil || p.alt == nil {
+		return latlong.alt == p.alt
+	}
+	return *latlong.alt == *p.alt
 }
 
 /*
@@ -286,6 +284,13 @@ func (latlong *Point) UnmarshalJSON(data []byte) (err error) {
 
 	if len(ll) > 2 {
 		altitude := ll[2].radian.Degrees()
+		// parse again not to lose digits by radian.
+		var raw []json.Number
+		if json.Unmarshal(bytes.TrimSpace(data), &raw) == nil {
+			if f, e := raw[2].Float64(); e == nil {
+				altitude = f
+			}
+		}
 		latlong.alt = &altitude
 	}
 
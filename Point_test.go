@@ -3,6 +3,7 @@ package latlong_test
 import (
 	"bytes"
 	"encoding/json"
+	"math"
 	"math/rand"
 	"testing"
 	"time"
@@ -102,6 +103,88 @@ func TestGridLocator(t *testing.T) {
 	}
 }
 
+func randomOpenLocationCode(n int) string {
+	const alphabet = "23456789CFGHJMPQRVWX"
+
+	bs := make([]byte, 0, n+1)
+	for i := 0; i < n; i++ {
+		switch {
+		case i == 0:
+			bs = append(bs, alphabet[rand.Intn(9)])
+		case i == 1:
+			bs = append(bs, alphabet[rand.Intn(18)])
+		default:
+			bs = append(bs, alphabet[rand.Intn(20)])
+		}
+	}
+	for len(bs) < 8 {
+		bs = append(bs, '0')
+	}
+	return string(bs[:8]) + "+" + string(bs[8:])
+}
+
+func TestOpenLocationCode(t *testing.T) {
+	randInit()
+
+	for _, n := range []int{2, 4, 6, 8, 10, 11, 12, 13, 14, 15} {
+		code := randomOpenLocationCode(n)
+		l, err := latlong.NewRectOpenLocationCode(code)
+		if err != nil {
+			t.Errorf("%s: %v", code, err)
+			continue
+		}
+		if olc := l.OpenLocationCode(); olc != code {
+			t.Errorf("expected %+v, was %+v", code, olc)
+		}
+	}
+
+	l, err := latlong.NewRectOpenLocationCode("9C3W9QCJ+2VX")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lat, lng := l.Center().Lat().Degrees(), l.Center().Lng().Degrees(); math.Abs(lat-51.3701125) > 1e-7 || math.Abs(lng+1.217765625) > 1e-7 {
+		t.Errorf("was %v %v", lat, lng)
+	}
+
+	for _, code := range []string{"", "9C3W9QCJ2VX", "9C3W9QCJ+2", "9C3W0QCJ+", "9C3W9Q00+2V", "WC3W9QCJ+2VX", "9C3W9QCA+2VX"} {
+		if _, err := latlong.NewRectOpenLocationCode(code); err == nil {
+			t.Errorf("%s: expected error", code)
+		}
+	}
+}
+
+func TestShortOpenLocationCode(t *testing.T) {
+	tests := []struct {
+		code     string
+		lat, lng float64
+		short    string
+	}{
+		{"9C3W9QCJ+2VX", 51.3701125, -1.217765625, "+2VX"},
+		{"9C3W9QCJ+2VX", 51.3708675, -1.217765625, "CJ+2VX"},
+		{"9C3W9QCJ+2VX", 51.3, -1.2, "9QCJ+2VX"},
+		{"9C3W9QCJ+2VX", 40, 10, "9C3W9QCJ+2VX"},
+	}
+	for _, tt := range tests {
+		ref := latlong.NewPoint(latlong.NewAngle(tt.lat, 0), latlong.NewAngle(tt.lng, 0), nil)
+		short, err := latlong.ShortenOpenLocationCode(tt.code, ref)
+		if err != nil || short != tt.short {
+			t.Errorf("expected %s, was %s %v", tt.short, short, err)
+		}
+		full, err := latlong.RecoverOpenLocationCode(tt.short, ref)
+		if err != nil || full != tt.code {
+			t.Errorf("expected %s, was %s %v", tt.code, full, err)
+		}
+	}
+
+	// nearest across 180 degrees.
+	ref := latlong.NewPoint(latlong.NewAngle(-16.5, 0), latlong.NewAngle(179.99, 0), nil)
+	code := latlong.NewRect(-16.5, -179.99, 0.0001, 0.0001).OpenLocationCode()
+	short, _ := latlong.ShortenOpenLocationCode(code, ref)
+	if full, err := latlong.RecoverOpenLocationCode(short, ref); err != nil || full != code {
+		t.Errorf("expected %s, was %s %v", code, full, err)
+	}
+}
+
 //
 
 func randInit() {
@@ -164,3 +247,19 @@ func TestGeoHash6(t *testing.T) {
 		t.Errorf("expected %+v, was %+v", correctResponsegh, gh)
 	}
 }
+
+func TestPointAltitude(t *testing.T) {
+	// altitude is compared by value, not by pointer.
+	alt0, alt1 := 12.3, 12.3
+	p0 := latlong.NewPoint(latlong.NewAngle(35.69, 0), latlong.NewAngle(139.71, 0), &alt0)
+	p1 := latlong.NewPoint(latlong.NewAngle(35.69, 0), latlong.NewAngle(139.71, 0), &alt1)
+	if !p0.Equal(p1) || p0.Equal(latlong.NewPoint(latlong.NewAngle(35.69, 0), latlong.NewAngle(139.71, 0), nil)) {
+		t.Error("altitude is not compared by value")
+	}
+
+	// altitude is not converted via radian.
+	var p latlong.Point
+	if err := json.Unmarshal([]byte(`[139.71,35.69,3840.328]`), &p); err != nil || p.Alt() == nil || *p.Alt() != 3840.328 {
+		t.Errorf("was %v", p.Alt())
+	}
+}
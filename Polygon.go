@@ -2,32 +2,50 @@ package latlong
 
 import (
 	"encoding/json"
+	"errors"
 
+	"github.com/golang/geo/r3"
 	"github.com/golang/geo/s2"
 )
 
-// Polygon inherited MultiPoint
+// Polygon is outer ring (LineString) with holes (interior rings).
 type Polygon struct {
 	LineString
+	Holes []LineString
 }
 
-/*
-// UnmarshalText is from ISO6709 latlongs.
+// NewPolygon is constructor for Polygon
+func NewPolygon(outer LineString, holes ...LineString) Polygon {
+	return Polygon{LineString: outer, Holes: holes}
+}
+
+// UnmarshalText is from ISO6709 latlongs of outer ring. Previous rings are reset.
 func (cds *Polygon) UnmarshalText(b []byte) error {
+	*cds = Polygon{}
 	return cds.MultiPoint.UnmarshalText(b)
 }
-*/
+
+// MarshalText is to ISO6709 latlongs of outer ring. ISO6709 has no holes, so it is error if cds has holes.
+func (cds Polygon) MarshalText() ([]byte, error) {
+	if len(cds.Holes) > 0 {
+		return nil, errors.New("ISO6709 cannot have holes of Polygon")
+	}
+	return cds.MultiPoint.MarshalText()
+}
 
 // Type returns this type
 func (Polygon) Type() string {
 	return "Polygon"
 }
 
-// S2Loop is getter for s2.Loop.
-func (cds Polygon) S2Loop() *s2.Loop {
-	ps := make(s2.Polyline, len(cds.MultiPoint))
-	for i := range cds.MultiPoint {
-		ps[i] = cds.MultiPoint[i].S2Point()
+// ringLoop returns CCW s2.Loop without closing vertex.
+func ringLoop(cds MultiPoint) *s2.Loop {
+	if l := len(cds); l > 1 && cds[0].S2LatLng() == cds[l-1].S2LatLng() {
+		cds = cds[:l-1]
+	}
+	ps := make([]s2.Point, len(cds))
+	for i := range cds {
+		ps[i] = cds[i].S2Point()
 	}
 	l := s2.LoopFromPoints(ps)
 	if !l.IsNormalized() {
@@ -36,44 +54,90 @@ func (cds Polygon) S2Loop() *s2.Loop {
 	return l
 }
 
+// S2Loop is getter for s2.Loop of outer ring.
+func (cds Polygon) S2Loop() *s2.Loop {
+	return ringLoop(cds.MultiPoint)
+}
+
+// S2Polygon is getter for s2.Polygon with holes.
+func (cds Polygon) S2Polygon() *s2.Polygon {
+	loops := []*s2.Loop{cds.S2Loop()}
+	for _, h := range cds.Holes {
+		loops = append(loops, ringLoop(h.MultiPoint))
+	}
+	return s2.PolygonFromLoops(loops)
+}
+
 // S2Region is getter for s2.Region.
 func (cds Polygon) S2Region() s2.Region {
-	return cds.S2Loop()
+	return cds.S2Polygon()
 }
 
 // CapBound is for s2.Region interface.
 func (cds *Polygon) CapBound() s2.Cap {
-	return cds.S2Loop().CapBound()
+	return cds.S2Polygon().CapBound()
 }
 
 // RectBound is for s2.Region interface.
 func (cds *Polygon) RectBound() s2.Rect {
-	return cds.S2Loop().RectBound()
+	return cds.S2Polygon().RectBound()
 }
 
 // ContainsCell is for s2.Region interface.
 func (cds *Polygon) ContainsCell(c s2.Cell) bool {
-	return cds.S2Loop().ContainsCell(c)
+	return cds.S2Polygon().ContainsCell(c)
 }
 
 // IntersectsCell is for s2.Region interface.
 func (cds *Polygon) IntersectsCell(c s2.Cell) bool {
-	return cds.S2Loop().IntersectsCell(c)
+	return cds.S2Polygon().IntersectsCell(c)
 }
 
 // ContainsPoint is for s2.Region interface.
 func (cds *Polygon) ContainsPoint(p s2.Point) bool {
-	return cds.S2Loop().ContainsPoint(p)
+	return cds.S2Polygon().ContainsPoint(p)
 }
 
 // CellUnionBound is for s2.Region interface.
 func (cds *Polygon) CellUnionBound() []s2.CellID {
-	return cds.S2Loop().CellUnionBound()
+	return cds.S2Polygon().CellUnionBound()
 }
 
 // S2Point is Center LatLng
 func (cds Polygon) S2Point() s2.Point {
-	return cds.S2Loop().Centroid()
+	return cds.Centroid()
+}
+
+// Centroid returns centroid of the area excluding holes.
+func (cds Polygon) Centroid() s2.Point {
+	return s2.Point{Vector: cds.centroidVector().Normalize()}
+}
+
+// centroidVector is centroid multiplied by area.
+func (cds Polygon) centroidVector() (v r3.Vector) {
+	for _, l := range cds.S2Polygon().Loops() {
+		v = v.Add(l.Centroid().Mul(float64(l.Sign())))
+	}
+	return
+}
+
+// AreaAngle returns area excluding holes in steradian.
+func (cds Polygon) AreaAngle() float64 {
+	return cds.S2Polygon().Area()
+}
+
+// Equal return bool
+func (cds Polygon) Equal(c1 Geometry) bool {
+	c, ok := c1.(Polygon)
+	if !ok || len(cds.Holes) != len(c.Holes) || !cds.MultiPoint.Equal(c.MultiPoint) {
+		return false
+	}
+	for i := range cds.Holes {
+		if !cds.Holes[i].MultiPoint.Equal(c.Holes[i].MultiPoint) {
+			return false
+		}
+	}
+	return true
 }
 
 // Radiusp is un-used
@@ -83,24 +147,27 @@ func (cds Polygon) Radiusp() *float64 {
 
 // MarshalJSON is a marshaler for JSON.
 func (cds Polygon) MarshalJSON() ([]byte, error) {
-	return json.Marshal(&[]MultiPoint{cds.MultiPoint})
+	rings := []MultiPoint{cds.MultiPoint}
+	for _, h := range cds.Holes {
+		rings = append(rings, h.MultiPoint)
+	}
+	return json.Marshal(&rings)
 }
 
 // UnmarshalJSON is a unmarshaler for JSON.
 func (cds *Polygon) UnmarshalJSON(data []byte) (err error) {
 	var co []MultiPoint
-	err = json.Unmarshal(data, &co)
-	if err != nil {
-		panic(err)
+	if err = json.Unmarshal(data, &co); err != nil {
+		return
 	}
 
-	switch len(co) {
-	case 0:
-		panic("No Polygon!")
-	case 1:
-		cds.MultiPoint = co[0]
-	default:
-		panic("Polygon has hole! Not implemented")
+	if len(co) == 0 {
+		return errors.New("no ring in Polygon")
+	}
+	cds.MultiPoint = co[0]
+	cds.Holes = nil
+	for _, h := range co[1:] {
+		cds.Holes = append(cds.Holes, LineString{MultiPoint: h})
 	}
 	return nil
 }
@@ -3,6 +3,7 @@ package latlong_test
 import (
 	"encoding/json"
 	"encoding/xml"
+	"math"
 	"testing"
 
 	"github.com/golang/geo/s2"
@@ -38,3 +39,59 @@ func TestPolygon1(t *testing.T) {
 		t.Error("Something wrong.")
 	}
 }
+
+func TestPolygonHole(t *testing.T) {
+	const jsonstring = `[
+	[ [100.0, 0.0], [101.0, 0.0], [101.0, 1.0], [100.0, 1.0], [100.0, 0.0] ],
+	[ [100.2, 0.2], [100.8, 0.2], [100.8, 0.8], [100.2, 0.8], [100.2, 0.2] ] ]`
+
+	var p latlong.Polygon
+	if err := json.Unmarshal([]byte(jsonstring), &p); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Holes) != 1 {
+		t.Fatalf("expected 1 hole, was %d", len(p.Holes))
+	}
+
+	if p.ContainsPoint(s2.PointFromLatLng(s2.LatLngFromDegrees(0.5, 100.5))) {
+		t.Error("point in hole is contained.")
+	}
+	if !p.ContainsPoint(s2.PointFromLatLng(s2.LatLngFromDegrees(0.1, 100.1))) {
+		t.Error("point in shell is not contained.")
+	}
+
+	outer := latlong.NewPolygon(p.LineString)
+	if a, expct := p.AreaAngle(), outer.AreaAngle()*0.64; math.Abs(a-expct) > expct*1e-3 {
+		t.Errorf("area expected %v, was %v", expct, a)
+	}
+
+	if r := p.RectBound(); !r.Contains(outer.RectBound()) {
+		t.Errorf("RectBound %v", r)
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var p1 latlong.Polygon
+	if err := json.Unmarshal(b, &p1); err != nil {
+		t.Fatal(err)
+	}
+	if !p.Equal(p1) {
+		t.Errorf("round trip mismatch %s", string(b))
+	}
+
+	if err := json.Unmarshal([]byte(`[]`), &p1); err == nil {
+		t.Error("expected error for empty polygon")
+	}
+
+	if _, err := xml.Marshal(p); err == nil {
+		t.Error("expected error for ISO6709 of polygon with holes")
+	}
+	if _, err := outer.MarshalText(); err != nil {
+		t.Error(err)
+	}
+	if err := p.UnmarshalText([]byte(`+00+100/+00+101/+01+101/+00+100/`)); err != nil || len(p.Holes) != 0 || len(p.MultiPoint) != 4 {
+		t.Errorf("was %v %v", p, err)
+	}
+}
@@ -0,0 +1,291 @@
+package latlong
+
+import (
+	"math"
+
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+)
+
+// pointTolerance is tolerance of a point on an edge, about 0.6 micrometers.
+const pointTolerance = s1.Angle(1e-13)
+
+// geoParts returns parts of g, which are s2.Point, s2.Polyline, *s2.Polygon or s2.Cap.
+func geoParts(g Geometry) (parts []interface{}) {
+	switch g := g.(type) {
+	case Point:
+		parts = append(parts, g.S2Point())
+	case MultiPoint:
+		for i := range g {
+			parts = append(parts, g[i].S2Point())
+		}
+	case LineString:
+		switch len(g.MultiPoint) {
+		case 0:
+		case 1:
+			parts = append(parts, g.MultiPoint[0].S2Point())
+		default:
+			parts = append(parts, g.S2Polyline())
+		}
+	case MultiLineString:
+		for i := range g {
+			parts = append(parts, geoParts(g[i])...)
+		}
+	case Polygon:
+		if len(g.MultiPoint) > 0 {
+			parts = append(parts, g.S2Polygon())
+		}
+	case MultiPolygon:
+		for i := range g {
+			parts = append(parts, geoParts(g[i])...)
+		}
+	case Circle:
+		if c := g.S2Cap(); !c.IsEmpty() {
+			parts = append(parts, c)
+		}
+	case GeometryCollection:
+		for i := range g {
+			parts = append(parts, geoParts(g[i])...)
+		}
+	}
+	return
+}
+
+func partRank(part interface{}) int {
+	switch part.(type) {
+	case s2.Point:
+		return 0
+	case s2.Polyline:
+		return 1
+	case *s2.Polygon:
+		return 2
+	}
+	return 3
+}
+
+// polygonEdges calls f for each edge of polygon until f returns true.
+func polygonEdges(pg *s2.Polygon, f func(a, b s2.Point) bool) bool {
+	for _, l := range pg.Loops() {
+		n := l.NumVertices()
+		for i := 0; i < n; i++ {
+			if f(l.Vertex(i), l.Vertex(i+1)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// polylineDistance returns distance from p to pl.
+func polylineDistance(p s2.Point, pl s2.Polyline) s1.Angle {
+	if len(pl) == 1 {
+		return p.Distance(pl[0])
+	}
+	d := s1.InfAngle()
+	for i := 1; i < len(pl); i++ {
+		if dd := s2.DistanceFromSegment(p, pl[i-1], pl[i]); dd < d {
+			d = dd
+		}
+	}
+	return d
+}
+
+// polygonBoundaryDistance returns distance from p to boundary of pg.
+func polygonBoundaryDistance(p s2.Point, pg *s2.Polygon) s1.Angle {
+	d := s1.InfAngle()
+	polygonEdges(pg, func(a, b s2.Point) bool {
+		if dd := s2.DistanceFromSegment(p, a, b); dd < d {
+			d = dd
+		}
+		return false
+	})
+	return d
+}
+
+// partDistance returns distance from p to part, and 0 if p is in part.
+func partDistance(p s2.Point, part interface{}) s1.Angle {
+	switch part := part.(type) {
+	case s2.Point:
+		return p.Distance(part)
+	case s2.Polyline:
+		return polylineDistance(p, part)
+	case *s2.Polygon:
+		if part.ContainsPoint(p) {
+			return 0
+		}
+		return polygonBoundaryDistance(p, part)
+	case s2.Cap:
+		return s1.Angle(math.Max(0, float64(p.Distance(part.Center())-part.Radius())))
+	}
+	return s1.InfAngle()
+}
+
+// geometryDistance returns the shortest distance from p to g, and 0 if p is in g.
+// It is infinity if g is empty.
+func geometryDistance(p s2.Point, g Geometry) s1.Angle {
+	d := s1.InfAngle()
+	for _, part := range geoParts(g) {
+		if dd := partDistance(p, part); dd < d {
+			d = dd
+		}
+	}
+	return d
+}
+
+// polylineCrossesPolygon reports whether edges of pl cross boundary of pg.
+func polylineCrossesPolygon(pl s2.Polyline, pg *s2.Polygon, proper bool) bool {
+	for i := 1; i < len(pl); i++ {
+		if polygonEdges(pg, func(a, b s2.Point) bool {
+			c := s2.CrossingSign(pl[i-1], pl[i], a, b)
+			return c == s2.Cross || (!proper && c == s2.MaybeCross)
+		}) {
+			return true
+		}
+	}
+	return false
+}
+
+// intersectsPart reports whether a and b intersect.
+func intersectsPart(a, b interface{}) bool {
+	if partRank(a) > partRank(b) {
+		a, b = b, a
+	}
+	switch a := a.(type) {
+	case s2.Point:
+		return partDistance(a, b) <= pointTolerance
+	case s2.Polyline:
+		switch b := b.(type) {
+		case s2.Polyline:
+			if a.Intersects(&b) {
+				return true
+			}
+			for i := range a {
+				if polylineDistance(a[i], b) <= pointTolerance {
+					return true
+				}
+			}
+			for i := range b {
+				if polylineDistance(b[i], a) <= pointTolerance {
+					return true
+				}
+			}
+			return false
+		case *s2.Polygon:
+			for i := range a {
+				if b.ContainsPoint(a[i]) {
+					return true
+				}
+			}
+			return polylineCrossesPolygon(a, b, false)
+		case s2.Cap:
+			return polylineDistance(b.Center(), a) <= b.Radius()
+		}
+	case *s2.Polygon:
+		switch b := b.(type) {
+		case *s2.Polygon:
+			return a.Intersects(b)
+		case s2.Cap:
+			return a.ContainsPoint(b.Center()) || polygonBoundaryDistance(b.Center(), a) <= b.Radius()
+		}
+	case s2.Cap:
+		return a.Center().Distance(b.(s2.Cap).Center()) <= a.Radius()+b.(s2.Cap).Radius()
+	}
+	return false
+}
+
+// containsPart reports whether a contains b.
+// Containment by a polyline is tested at vertices and midpoints of edges.
+func containsPart(a, b interface{}) bool {
+	switch a := a.(type) {
+	case s2.Point:
+		p, ok := b.(s2.Point)
+		return ok && a.Distance(p) <= pointTolerance
+	case s2.Polyline:
+		switch b := b.(type) {
+		case s2.Point:
+			return polylineDistance(b, a) <= pointTolerance
+		case s2.Polyline:
+			for i := range b {
+				if polylineDistance(b[i], a) > pointTolerance {
+					return false
+				}
+				if i > 0 && polylineDistance(s2.Interpolate(0.5, b[i-1], b[i]), a) > pointTolerance {
+					return false
+				}
+			}
+			return true
+		}
+		return false
+	case *s2.Polygon:
+		switch b := b.(type) {
+		case s2.Point:
+			return a.ContainsPoint(b)
+		case s2.Polyline:
+			for i := range b {
+				if !a.ContainsPoint(b[i]) {
+					return false
+				}
+			}
+			return !polylineCrossesPolygon(b, a, true)
+		case *s2.Polygon:
+			return a.Contains(b)
+		case s2.Cap:
+			return a.ContainsPoint(b.Center()) && polygonBoundaryDistance(b.Center(), a) >= b.Radius()
+		}
+	case s2.Cap:
+		switch b := b.(type) {
+		case s2.Point:
+			return a.ContainsPoint(b)
+		case s2.Polyline:
+			for i := range b {
+				if !a.ContainsPoint(b[i]) {
+					return false
+				}
+			}
+			return true
+		case *s2.Polygon:
+			return !polygonEdges(b, func(v, _ s2.Point) bool {
+				return !a.ContainsPoint(v)
+			})
+		case s2.Cap:
+			return a.Contains(b)
+		}
+	}
+	return false
+}
+
+// Intersects reports whether a and b have any point in common.
+func Intersects(a, b Geometry) bool {
+	bparts := geoParts(b)
+	for _, ap := range geoParts(a) {
+		for _, bp := range bparts {
+			if intersectsPart(ap, bp) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Contains reports whether a contains b. Each part of b, such as a Polygon of MultiPolygon,
+// must be contained by a part of a. It is false if b is empty.
+func Contains(a, b Geometry) bool {
+	aparts := geoParts(a)
+	bparts := geoParts(b)
+	if len(bparts) == 0 {
+		return false
+	}
+	for _, bp := range bparts {
+		contained := false
+		for _, ap := range aparts {
+			if containsPart(ap, bp) {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+package latlong_test
+
+import (
+	"testing"
+
+	latlong "github.com/toyo/go-latlong"
+)
+
+func TestIntersectsContains(t *testing.T) {
+	pt := func(lat, lng float64) latlong.Point {
+		return latlong.NewPoint(latlong.NewAngle(lat, 0), latlong.NewAngle(lng, 0), nil)
+	}
+	square := latlong.NewPolygon(latlong.LineString{MultiPoint: latlong.MultiPoint{pt(0, 0), pt(0, 2), pt(2, 2), pt(2, 0)}},
+		latlong.LineString{MultiPoint: latlong.MultiPoint{pt(0.5, 0.5), pt(0.5, 1.5), pt(1.5, 1.5), pt(1.5, 0.5)}})
+	inner := latlong.LineString{MultiPoint: latlong.MultiPoint{pt(0.1, 0.1), pt(0.1, 1.9)}}
+	acrosshole := latlong.LineString{MultiPoint: latlong.MultiPoint{pt(0.1, 0.1), pt(1.9, 1.9)}}
+	outside := latlong.LineString{MultiPoint: latlong.MultiPoint{pt(3, 3), pt(3, 4)}}
+	crossing := latlong.LineString{MultiPoint: latlong.MultiPoint{pt(1, -1), pt(1, 0.2)}}
+	touching := latlong.LineString{MultiPoint: latlong.MultiPoint{pt(-1, -1), pt(0, 0)}}
+	equator := latlong.LineString{MultiPoint: latlong.MultiPoint{pt(0, 10), pt(0, 12)}}
+	circle := *latlong.NewCircle(pt(3, 3), 200)
+	bigcircle := *latlong.NewCircle(pt(1, 1), 500)
+
+	for i, c := range []struct {
+		a, b                 latlong.Geometry
+		intersects, contains bool
+	}{
+		{square, pt(0.2, 0.2), true, true},
+		{square, pt(1, 1), false, false}, // in hole
+		{square, inner, true, true},
+		{square, acrosshole, true, false},
+		{square, outside, false, false},
+		{square, crossing, true, false},
+		{square, touching, true, false},
+		{square, circle, true, false},
+		{bigcircle, square, true, true},
+		{bigcircle, latlong.MultiPoint{pt(1, 1), pt(10, 10)}, true, false},
+		{equator, pt(0, 11), true, true},
+		{equator, latlong.LineString{MultiPoint: latlong.MultiPoint{pt(0, 10.5), pt(0, 11)}}, true, true},
+		{equator, latlong.LineString{MultiPoint: latlong.MultiPoint{pt(0, 11), pt(0, 13)}}, true, false},
+		{latlong.GeometryCollection{outside, square}, latlong.MultiPoint{pt(3, 3), pt(0.2, 0.2)}, true, true},
+		{square, latlong.GeometryCollection{}, false, false},
+	} {
+		if latlong.Intersects(c.a, c.b) != c.intersects {
+			t.Errorf("%d: Intersects expected %v", i, c.intersects)
+		}
+		if latlong.Intersects(c.b, c.a) != c.intersects {
+			t.Errorf("%d: Intersects reverse expected %v", i, c.intersects)
+		}
+		if latlong.Contains(c.a, c.b) != c.contains {
+			t.Errorf("%d: Contains expected %v", i, c.contains)
+		}
+	}
+}
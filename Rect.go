@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"strings"
 	"unicode"
 
 	geohash "github.com/TomiHiltunen/geohash-golang"
@@ -236,6 +237,197 @@ func (rect *Rect) GeoHash() string {
 	return rect.geoHash(geohashlnglen)
 }
 
+// olcAlphabet is digits of Open Location Code.
+const olcAlphabet = "23456789CFGHJMPQRVWX"
+
+// olcLengths are valid lengths of Open Location Code without separator.
+var olcLengths = []int{2, 4, 6, 8, 10, 11, 12, 13, 14, 15}
+
+// olcSize returns size in degrees of Open Location Code with length.
+func olcSize(length int) (lat, lng float64) {
+	lat, lng = 400, 400
+	for i := 0; i < length && i < 10; i += 2 {
+		lat /= 20
+		lng /= 20
+	}
+	for i := 10; i < length; i++ {
+		lat /= 5
+		lng /= 4
+	}
+	return
+}
+
+// NewRectOpenLocationCode is from full Open Location Code (Plus Code).
+// https://github.com/google/open-location-code
+// Use RecoverOpenLocationCode for short code.
+func NewRectOpenLocationCode(code string) (*Rect, error) {
+	code = strings.ToUpper(code)
+	sep := strings.IndexByte(code, '+')
+	if sep != 8 || strings.Count(code, "+") != 1 {
+		return nil, errors.New("Open Location Code separator error")
+	}
+	digits := code[:sep]
+	if pad := strings.IndexByte(digits, '0'); pad >= 0 {
+		if pad == 0 || pad%2 != 0 || strings.Trim(digits[pad:], "0") != "" || len(code) != sep+1 {
+			return nil, errors.New("Open Location Code padding error")
+		}
+		digits = digits[:pad]
+	} else {
+		digits += code[sep+1:]
+		if len(digits) == 9 {
+			return nil, errors.New("Open Location Code length error")
+		}
+	}
+	if len(digits) > 15 {
+		digits = digits[:15]
+	}
+
+	latitude, longitude := float64(-90), float64(-180)
+	latprec, lngprec := float64(400), float64(400)
+	for i := 0; i < len(digits); i++ {
+		d := strings.IndexByte(olcAlphabet, digits[i])
+		if d < 0 {
+			return nil, errors.New("Open Location Code digit error")
+		}
+		switch {
+		case i < 10 && i%2 == 0:
+			latprec /= 20
+			latitude += latprec * float64(d)
+		case i < 10:
+			lngprec /= 20
+			longitude += lngprec * float64(d)
+		default:
+			latprec /= 5
+			lngprec /= 4
+			latitude += latprec * float64(d/4)
+			longitude += lngprec * float64(d%4)
+		}
+	}
+	if latitude >= 90 || longitude >= 180 {
+		return nil, errors.New("Open Location Code range error")
+	}
+	return NewRect(latitude+latprec/2, longitude+lngprec/2, latprec, lngprec), nil
+}
+
+// openLocationCode returns full Open Location Code of length at lat, lng in degrees.
+func openLocationCode(lat, lng float64, length int) string {
+	const (
+		latunits = 8000 * 3125 // 1/20^3 deg. divided by 5^5
+		lngunits = 8000 * 1024 // 1/20^3 deg. divided by 4^5
+	)
+	latval := int64(math.Floor(math.Round((lat+90)*latunits*1e6) / 1e6))
+	lngval := int64(math.Floor(math.Round((lng+180)*lngunits*1e6) / 1e6))
+	if latval < 0 {
+		latval = 0
+	} else if latval >= 180*latunits {
+		latval = 180*latunits - 1
+	}
+	lngval %= 360 * lngunits
+	if lngval < 0 {
+		lngval += 360 * lngunits
+	}
+
+	b := make([]byte, 15)
+	for i := 14; i >= 10; i-- {
+		b[i] = olcAlphabet[latval%5*4+lngval%4]
+		latval /= 5
+		lngval /= 4
+	}
+	for i := 8; i >= 0; i -= 2 {
+		b[i] = olcAlphabet[latval%20]
+		b[i+1] = olcAlphabet[lngval%20]
+		latval /= 20
+		lngval /= 20
+	}
+
+	b = b[:length]
+	for len(b) < 8 {
+		b = append(b, '0')
+	}
+	return string(b[:8]) + "+" + string(b[8:])
+}
+
+// OpenLocationCode returns Open Location Code (Plus Code).
+// The length is chosen from the size of rect,
+// and empty string is returned if rect is larger than 20 degrees.
+func (rect *Rect) OpenLocationCode() string {
+	const floaterr = 1 + 1e-6 // sizes of lengths differ by at least 4 times.
+
+	length := 0
+	for _, l := range olcLengths {
+		latsize, lngsize := olcSize(l)
+		if latsize*floaterr < rect.Size().Lat.Degrees() || lngsize*floaterr < rect.Size().Lng.Degrees() {
+			break
+		}
+		length = l
+	}
+	if length == 0 {
+		return ""
+	}
+	return openLocationCode(rect.Center().Lat().Degrees(), rect.Center().Lng().Degrees(), length)
+}
+
+// ShortenOpenLocationCode removes first 4, 6 or 8 digits of full Open Location Code
+// which can be recovered by RecoverOpenLocationCode with reference point near ref.
+func ShortenOpenLocationCode(code string, ref Point) (string, error) {
+	rect, err := NewRectOpenLocationCode(code)
+	if err != nil {
+		return "", err
+	}
+	if strings.IndexByte(code, '0') >= 0 {
+		return "", errors.New("Open Location Code padded code cannot be shortened")
+	}
+	dist := math.Max(
+		math.Abs(rect.Center().Lat().Degrees()-ref.Lat().Degrees()),
+		math.Abs(math.Remainder(rect.Center().Lng().Degrees()-ref.Lng().Degrees(), 360)))
+	for _, n := range []int{8, 6, 4} {
+		latsize, _ := olcSize(n)
+		if dist < latsize*0.3 && len(code) > n+1 {
+			return strings.ToUpper(code[n:]), nil
+		}
+	}
+	return code, nil
+}
+
+// RecoverOpenLocationCode returns full Open Location Code of short code
+// which is nearest to ref.
+func RecoverOpenLocationCode(short string, ref Point) (string, error) {
+	short = strings.ToUpper(short)
+	sep := strings.IndexByte(short, '+')
+	if sep == 8 {
+		if _, err := NewRectOpenLocationCode(short); err != nil {
+			return "", err
+		}
+		return short, nil
+	}
+	if sep < 0 || sep > 8 || sep%2 != 0 || strings.IndexByte(short, '0') >= 0 {
+		return "", errors.New("Open Location Code short code error")
+	}
+
+	padding := 8 - sep
+	reflat := math.Max(-90, math.Min(90, ref.Lat().Degrees()))
+	reflng := ref.Lng().Degrees()
+	code := openLocationCode(reflat, reflng, 10)[:padding] + short
+	rect, err := NewRectOpenLocationCode(code)
+	if err != nil {
+		return "", err
+	}
+
+	res, _ := olcSize(padding)
+	lat, lng := rect.Center().Lat().Degrees(), rect.Center().Lng().Degrees()
+	if reflat+res/2 < lat && lat-res >= -90 {
+		lat -= res
+	} else if reflat-res/2 > lat && lat+res <= 90 {
+		lat += res
+	}
+	if reflng+res/2 < lng {
+		lng -= res
+	} else if reflng-res/2 > lng {
+		lng += res
+	}
+	return openLocationCode(lat, lng, len(code)-1), nil
+}
+
 // S2Rect returns s2.Rect.
 func (rect *Rect) S2Rect() s2.Rect {
 	return rect.Rect
@@ -245,3 +437,80 @@ func (rect *Rect) S2Rect() s2.Rect {
 func (rect *Rect) S2Region() s2.Region {
 	return rect.S2Rect()
 }
+
+// IntersectsRegion reports whether rect intersects r.
+// Edges of rect are approximated by great circles.
+func (rect *Rect) IntersectsRegion(r s2.Region) bool {
+	switch r := r.(type) {
+	case s2.Point:
+		return rect.ContainsPoint(r)
+	case s2.Rect:
+		return rect.Intersects(r)
+	case *s2.Rect:
+		return rect.Intersects(*r)
+	case s2.Cap:
+		if rect.ContainsPoint(r.Center()) {
+			return true
+		}
+		vs := rect.s2Vertices()
+		for i := range vs {
+			if s2.DistanceFromSegment(r.Center(), vs[i], vs[(i+1)%4]) <= r.Radius() {
+				return true
+			}
+		}
+		return false
+	case *s2.Polyline:
+		pl := *r
+		for i := range pl {
+			if rect.ContainsPoint(pl[i]) {
+				return true
+			}
+		}
+		vs := rect.s2Vertices()
+		for i := 0; i+1 < len(pl); i++ {
+			for j := range vs {
+				if s2.CrossingSign(pl[i], pl[i+1], vs[j], vs[(j+1)%4]) == s2.Cross {
+					return true
+				}
+			}
+		}
+		return false
+	case *s2.Loop:
+		return s2.PolygonFromLoops([]*s2.Loop{r}).Intersects(rect.S2Polygon())
+	case *s2.Polygon:
+		return r.Intersects(rect.S2Polygon())
+	case s2.RegionUnion:
+		for _, rr := range r {
+			if rect.IntersectsRegion(rr) {
+				return true
+			}
+		}
+		return false
+	}
+	return rect.Intersects(r.RectBound())
+}
+
+// s2Vertices returns CCW vertices.
+func (rect *Rect) s2Vertices() []s2.Point {
+	vs := make([]s2.Point, 4)
+	for i := range vs {
+		vs[i] = s2.PointFromLatLng(rect.Vertex(i))
+	}
+	return vs
+}
+
+// S2Polygon returns s2.Polygon which edges are great circles.
+func (rect *Rect) S2Polygon() *s2.Polygon {
+	return s2.PolygonFromLoops([]*s2.Loop{s2.LoopFromPoints(rect.s2Vertices())})
+}
+
+// Polygon returns Polygon of 4 vertices with precision of 1/10 of rect size as MarshalJSON.
+func (rect *Rect) Polygon() Polygon {
+	cds := make(MultiPoint, 5)
+	for i := range cds {
+		cds[i] = Point{
+			lat: NewAngleFromS1Angle(rect.Rect.Vertex(i%4).Lat, rect.Rect.Size().Lat/10),
+			lng: NewAngleFromS1Angle(rect.Rect.Vertex(i%4).Lng, rect.Rect.Size().Lng/10)}
+	}
+	return NewPolygon(LineString{MultiPoint: cds})
+}
@@ -0,0 +1,335 @@
+package latlong
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+)
+
+// simplifyChain is vertices of LineString or closed ring, and flags of kept vertices.
+type simplifyChain struct {
+	ps   []s2.Point
+	keep []bool
+}
+
+func newSimplifyChain(cds MultiPoint, ring bool) *simplifyChain {
+	c := &simplifyChain{}
+	for i := range cds {
+		c.ps = append(c.ps, cds[i].S2Point())
+	}
+	if ring && len(c.ps) > 0 && c.ps[0] != c.ps[len(c.ps)-1] {
+		c.ps = append(c.ps, c.ps[0])
+	}
+	c.keep = make([]bool, len(c.ps))
+	if len(c.ps) > 0 {
+		c.keep[0] = true
+		c.keep[len(c.ps)-1] = true
+	}
+	return c
+}
+
+// farthest returns the vertex farthest from segment i-j between i and j, or -1.
+func (c *simplifyChain) farthest(i, j int) (k int, d s1.Angle) {
+	k = -1
+	for m := i + 1; m < j; m++ {
+		if dd := s2.DistanceFromSegment(c.ps[m], c.ps[i], c.ps[j]); k < 0 || dd > d {
+			k, d = m, dd
+		}
+	}
+	return
+}
+
+// douglasPeucker keeps vertices farther than tolerance from simplified segments.
+func (c *simplifyChain) douglasPeucker(tolerance s1.Angle) {
+	type span struct{ i, j int }
+	stack := []span{{0, len(c.ps) - 1}}
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if k, d := c.farthest(s.i, s.j); k >= 0 && (d > tolerance || c.ps[s.i] == c.ps[s.j]) {
+			c.keep[k] = true
+			stack = append(stack, span{s.i, k}, span{k, s.j})
+		}
+	}
+}
+
+// vwVertex is vertex of Visvalingam-Whyatt in heap.
+type vwVertex struct {
+	i, prev, next int
+	area          float64
+	index         int // in heap.
+}
+
+type vwHeap []*vwVertex
+
+func (h vwHeap) Len() int { return len(h) }
+func (h vwHeap) Less(i, j int) bool {
+	if h[i].area != h[j].area {
+		return h[i].area < h[j].area
+	}
+	return h[i].i < h[j].i
+}
+func (h vwHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+func (h *vwHeap) Push(x interface{}) {
+	v := x.(*vwVertex)
+	v.index = len(*h)
+	*h = append(*h, v)
+}
+func (h *vwHeap) Pop() interface{} {
+	old := *h
+	v := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return v
+}
+
+// visvalingam keeps vertices which effective area is not less than area in steradians.
+func (c *simplifyChain) visvalingam(area float64) {
+	n := len(c.ps)
+	if n < 3 {
+		return
+	}
+	vs := make([]*vwVertex, n)
+	h := make(vwHeap, 0, n)
+	for i := 1; i < n-1; i++ {
+		vs[i] = &vwVertex{i: i, prev: i - 1, next: i + 1, area: s2.PointArea(c.ps[i-1], c.ps[i], c.ps[i+1])}
+		heap.Push(&h, vs[i])
+	}
+	for i := 1; i < n-1; i++ {
+		c.keep[i] = true
+	}
+	last := 0.0
+	for h.Len() > 0 {
+		v := heap.Pop(&h).(*vwVertex)
+		// effective area is not less than that of removed vertices.
+		if v.area < last {
+			v.area = last
+		}
+		if v.area >= area {
+			break
+		}
+		last = v.area
+		c.keep[v.i] = false
+		for _, j := range []int{v.prev, v.next} {
+			if u := vs[j]; u != nil {
+				if j == v.prev {
+					u.next = v.next
+				} else {
+					u.prev = v.prev
+				}
+				u.area = s2.PointArea(c.ps[u.prev], c.ps[u.i], c.ps[u.next])
+				heap.Fix(&h, u.index)
+			}
+		}
+	}
+}
+
+// segments returns pairs of consecutive kept vertices.
+func (c *simplifyChain) segments() (segs [][2]int) {
+	prev := -1
+	for i := range c.keep {
+		if c.keep[i] {
+			if prev >= 0 {
+				segs = append(segs, [2]int{prev, i})
+			}
+			prev = i
+		}
+	}
+	return
+}
+
+// ensureRing keeps at least 3 distinct vertices of ring.
+func (c *simplifyChain) ensureRing() {
+	for len(c.segments()) < 3 {
+		best, bestd := -1, s1.Angle(-1)
+		for _, s := range c.segments() {
+			if k, d := c.farthest(s[0], s[1]); k >= 0 && d > bestd {
+				best, bestd = k, d
+			}
+		}
+		if best < 0 {
+			return
+		}
+		c.keep[best] = true
+	}
+}
+
+func (c *simplifyChain) multiPoint(cds MultiPoint) (s MultiPoint) {
+	for i := range c.keep {
+		if c.keep[i] {
+			if i < len(cds) {
+				s = append(s, cds[i])
+			} else {
+				s = append(s, cds[0]) // closing vertex.
+			}
+		}
+	}
+	return
+}
+
+// preserveTopology restores vertices until simplified edges of chains do not cross each other,
+// and holes are inside the outer ring as before.
+func preserveTopology(chains []*simplifyChain) {
+	type edge struct {
+		c    int
+		i, j int
+		last bool
+	}
+	for {
+		var edges []edge
+		for ci, c := range chains {
+			segs := c.segments()
+			for k, s := range segs {
+				edges = append(edges, edge{ci, s[0], s[1], k == len(segs)-1})
+			}
+		}
+		bad := make(map[int]bool)
+		for a := range edges {
+			ea := edges[a]
+			pa0, pa1 := chains[ea.c].ps[ea.i], chains[ea.c].ps[ea.j]
+			for b := a + 1; b < len(edges); b++ {
+				eb := edges[b]
+				if ea.c == eb.c && (ea.j == eb.i || (eb.last && ea.i == 0)) {
+					continue // adjacent edges.
+				}
+				pb0, pb1 := chains[eb.c].ps[eb.i], chains[eb.c].ps[eb.j]
+				if s2.CrossingSign(pa0, pa1, pb0, pb1) != s2.DoNotCross {
+					bad[a] = true
+					bad[b] = true
+				}
+			}
+		}
+		split := false
+		for a := range bad {
+			e := edges[a]
+			if k, _ := chains[e.c].farthest(e.i, e.j); k >= 0 {
+				chains[e.c].keep[k] = true
+				split = true
+			}
+		}
+		if !split && len(bad) == 0 {
+			split = chains[0].restoreContaining(chains[1:])
+		}
+		if !split {
+			return
+		}
+	}
+}
+
+// loop returns s2.Loop of vertices of c, or only kept vertices if kept.
+func (c *simplifyChain) loop(kept bool) *s2.Loop {
+	var ps []s2.Point
+	for i := 0; i+1 < len(c.ps); i++ {
+		if !kept || c.keep[i] {
+			ps = append(ps, c.ps[i])
+		}
+	}
+	return s2.LoopFromPoints(ps)
+}
+
+// restoreContaining restores vertex of outer ring c nearest to holes which are no longer inside c.
+// It returns true if restored.
+func (c *simplifyChain) restoreContaining(holes []*simplifyChain) bool {
+	if len(c.ps) < 4 {
+		return false
+	}
+	orig, simple := c.loop(false), c.loop(true)
+	for _, h := range holes {
+		if len(h.ps) == 0 || orig.ContainsPoint(h.ps[0]) == simple.ContainsPoint(h.ps[0]) {
+			continue
+		}
+		best, bestd := -1, s1.InfAngle()
+		for _, s := range c.segments() {
+			if k, _ := c.farthest(s[0], s[1]); k >= 0 {
+				if d := s2.DistanceFromSegment(h.ps[0], c.ps[s[0]], c.ps[s[1]]); d < bestd {
+					best, bestd = k, d
+				}
+			}
+		}
+		if best >= 0 {
+			c.keep[best] = true
+			return true
+		}
+	}
+	return false
+}
+
+// SimplifyDP returns LineString simplified by Douglas-Peucker with tolerance of distance
+// from great circle segments.
+func (cds LineString) SimplifyDP(tolerance Km) LineString {
+	c := newSimplifyChain(cds.MultiPoint, false)
+	c.douglasPeucker(tolerance.EarthAngle())
+	return LineString{MultiPoint: c.multiPoint(cds.MultiPoint)}
+}
+
+// SimplifyVW returns LineString simplified by Visvalingam-Whyatt.
+// Vertices which effective area of spherical triangle is less than tolerance^2 are removed.
+func (cds LineString) SimplifyVW(tolerance Km) LineString {
+	c := newSimplifyChain(cds.MultiPoint, false)
+	c.visvalingam(float64(tolerance.EarthAngle() * tolerance.EarthAngle()))
+	return LineString{MultiPoint: c.multiPoint(cds.MultiPoint)}
+}
+
+func (cds Polygon) simplify(f func(*simplifyChain), preserve bool) Polygon {
+	rings := append([]LineString{cds.LineString}, cds.Holes...)
+	chains := make([]*simplifyChain, len(rings))
+	for i := range rings {
+		chains[i] = newSimplifyChain(rings[i].MultiPoint, true)
+		f(chains[i])
+		if preserve || i == 0 {
+			chains[i].ensureRing()
+		}
+	}
+	if preserve {
+		preserveTopology(chains)
+	}
+
+	var p Polygon
+	for i := range rings {
+		ring := LineString{MultiPoint: chains[i].multiPoint(rings[i].MultiPoint)}
+		if i == 0 {
+			p.LineString = ring
+		} else if len(chains[i].segments()) >= 3 {
+			p.Holes = append(p.Holes, ring)
+		}
+	}
+	return p
+}
+
+// SimplifyDP returns Polygon simplified by Douglas-Peucker. Rings are closed.
+// Outer ring keeps at least 3 vertices. If preserveTopology, holes also keep at least 3 vertices
+// and edges do not cross each other. Otherwise holes collapsed to less than 3 vertices are removed.
+func (cds Polygon) SimplifyDP(tolerance Km, preserveTopology bool) Polygon {
+	return cds.simplify(func(c *simplifyChain) {
+		c.douglasPeucker(tolerance.EarthAngle())
+	}, preserveTopology)
+}
+
+// SimplifyVW returns Polygon simplified by Visvalingam-Whyatt as LineString.SimplifyVW.
+// preserveTopology is same as SimplifyDP.
+func (cds Polygon) SimplifyVW(tolerance Km, preserveTopology bool) Polygon {
+	return cds.simplify(func(c *simplifyChain) {
+		c.visvalingam(float64(tolerance.EarthAngle() * tolerance.EarthAngle()))
+	}, preserveTopology)
+}
+
+// ZoomTolerance returns size of a pixel at the equator on 256 pixels tile of Web Mercator zoom level.
+func ZoomTolerance(zoom int) Km {
+	return Km(2 * math.Pi * radiusKmOfTheEarth / 256 / math.Pow(2, float64(zoom)))
+}
+
+// SimplifyZoom returns LineString simplified by Douglas-Peucker for display at zoom level.
+func (cds LineString) SimplifyZoom(zoom int) LineString {
+	return cds.SimplifyDP(ZoomTolerance(zoom))
+}
+
+// SimplifyZoom returns Polygon simplified by Douglas-Peucker with topology preserved
+// for display at zoom level.
+func (cds Polygon) SimplifyZoom(zoom int) Polygon {
+	return cds.SimplifyDP(ZoomTolerance(zoom), true)
+}
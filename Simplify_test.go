@@ -0,0 +1,119 @@
+package latlong_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/s2"
+
+	latlong "github.com/toyo/go-latlong"
+)
+
+func TestSimplifyLineString(t *testing.T) {
+	pt := func(lat, lng float64) latlong.Point {
+		return latlong.NewPoint(latlong.NewAngle(lat, 0), latlong.NewAngle(lng, 0), nil)
+	}
+	// zigzag along the equator with amplitude 0.001 degrees (about 111m) and a peak of 1 degree.
+	var ls latlong.LineString
+	for i := 0; i <= 100; i++ {
+		lat := 0.001 * float64(i%2)
+		if i == 50 {
+			lat = 1
+		}
+		ls.MultiPoint = append(ls.MultiPoint, pt(lat, float64(i)*0.1))
+	}
+
+	s := ls.SimplifyDP(1)
+	if len(s.MultiPoint) != 5 || !s.MultiPoint[0].Equal(ls.MultiPoint[0]) || !s.MultiPoint[2].Equal(ls.MultiPoint[50]) ||
+		!s.MultiPoint[len(s.MultiPoint)-1].Equal(ls.MultiPoint[100]) {
+		t.Errorf("was %d %v", len(s.MultiPoint), s)
+	}
+	if s := ls.SimplifyDP(0.05); len(s.MultiPoint) != len(ls.MultiPoint) {
+		t.Errorf("expected no simplification, was %d", len(s.MultiPoint))
+	}
+
+	// the peak and its neighbours are kept as their triangles are large.
+	if s := ls.SimplifyVW(5); len(s.MultiPoint) != 7 || !s.MultiPoint[len(s.MultiPoint)/2].Equal(ls.MultiPoint[50]) {
+		t.Errorf("was %d %v", len(s.MultiPoint), s)
+	}
+	if s := ls.SimplifyVW(0.01); len(s.MultiPoint) != len(ls.MultiPoint) {
+		t.Errorf("expected no simplification, was %d", len(s.MultiPoint))
+	}
+
+	if z0, z10 := latlong.ZoomTolerance(0), latlong.ZoomTolerance(10); math.Abs(float64(z0)-156.4) > 0.1 || math.Abs(float64(z0/z10)-1024) > 1e-9 {
+		t.Errorf("was %v %v", z0, z10)
+	}
+	if s0, s20 := ls.SimplifyZoom(0), ls.SimplifyZoom(20); len(s0.MultiPoint) >= len(s20.MultiPoint) {
+		t.Errorf("was %d %d", len(s0.MultiPoint), len(s20.MultiPoint))
+	}
+}
+
+func TestSimplifyPolygon(t *testing.T) {
+	pt := func(lat, lng float64) latlong.Point {
+		return latlong.NewPoint(latlong.NewAngle(lat, 0), latlong.NewAngle(lng, 0), nil)
+	}
+	// square with a narrow notch from the top which reaches near the hole.
+	outer := latlong.LineString{MultiPoint: latlong.MultiPoint{
+		pt(0, 0), pt(0, 2), pt(2, 2), pt(2, 1.01), pt(1.2, 1.01), pt(1.2, 0.99), pt(2, 0.99), pt(2, 0),
+	}}
+	hole := latlong.LineString{MultiPoint: latlong.MultiPoint{pt(0.5, 0.5), pt(0.5, 1.5), pt(1.1, 1.5), pt(1.1, 0.5)}}
+	small := latlong.LineString{MultiPoint: latlong.MultiPoint{pt(0.2, 0.2), pt(0.2, 0.21), pt(0.21, 0.21)}}
+	pg := latlong.NewPolygon(outer, hole, small)
+
+	s := pg.SimplifyDP(5, false)
+	if len(s.Holes) != 1 || len(s.MultiPoint) != 8 {
+		t.Errorf("was %v", s)
+	}
+
+	s = pg.SimplifyDP(100, false)
+	if !validPolygon(s) {
+		t.Logf("not valid without preserving topology %v", s)
+	}
+	s = pg.SimplifyDP(100, true)
+	if len(s.Holes) != 2 || !validPolygon(s) {
+		t.Errorf("was %v", s)
+	}
+	if s.MultiPoint[0].Equal(s.MultiPoint[len(s.MultiPoint)-1]) == false {
+		t.Errorf("not closed %v", s)
+	}
+	if s := pg.SimplifyVW(100, true); !validPolygon(s) || len(s.MultiPoint) < 4 {
+		t.Errorf("was %v", s)
+	}
+	if s := pg.SimplifyZoom(0); !validPolygon(s) {
+		t.Errorf("was %v", s)
+	}
+	// hole in a spike of the outer ring, which is removed without preserving topology.
+	spike := latlong.NewPolygon(latlong.LineString{MultiPoint: latlong.MultiPoint{
+		pt(0, 0), pt(0, 10), pt(10, 10), pt(10, 5.5), pt(10.6, 5), pt(10, 4.5), pt(10, 0),
+	}}, latlong.LineString{MultiPoint: latlong.MultiPoint{pt(10.1, 4.95), pt(10.1, 5.05), pt(10.2, 5)}})
+	if s := spike.SimplifyDP(80, false); len(s.MultiPoint) != 5 {
+		t.Errorf("was %v", s)
+	}
+	if s := spike.SimplifyDP(80, true); len(s.Holes) != 1 || !validPolygon(s) ||
+		!s.S2Loop().ContainsPoint(pt(10.15, 5).S2Point()) {
+		t.Errorf("was %v", s)
+	}
+}
+
+func validPolygon(pg latlong.Polygon) bool {
+	var loops []*s2.Loop
+	for _, r := range append([]latlong.LineString{pg.LineString}, pg.Holes...) {
+		ps := r.S2Polyline()
+		ps = ps[:len(ps)-1]
+		loops = append(loops, s2.LoopFromPoints(ps))
+	}
+	for i := range loops {
+		if loops[i].Validate() != nil {
+			return false
+		}
+		for j := i + 1; j < len(loops); j++ {
+			if loops[i].BoundaryEqual(loops[j]) {
+				return false
+			}
+			if i > 0 && loops[i].Intersects(loops[j]) {
+				return false
+			}
+		}
+	}
+	return s2.PolygonFromLoops(loops).Validate() == nil
+}
@@ -0,0 +1,260 @@
+package latlong
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+)
+
+// UTM is a coordinate of Universal Transverse Mercator on WGS84.
+type UTM struct {
+	Zone     int     // 1 to 60
+	Band     byte    // latitude band 'C' to 'X'. 'N' or later is northern hemisphere.
+	Easting  float64 // meters with false easting 500km.
+	Northing float64 // meters with false northing 10000km in southern hemisphere.
+}
+
+const (
+	utmBands          = "CDEFGHJKLMNPQRSTUVWX"
+	utmScale          = 0.9996
+	utmFalseEasting   = 500000
+	utmFalseNorthingS = 10000000
+)
+
+var utmGaussKruger = newGaussKruger(WGS84, utmScale)
+
+func (utm UTM) String() string {
+	return strconv.Itoa(utm.Zone) + string(utm.Band) + " " +
+		strconv.FormatFloat(utm.Easting, 'f', 0, 64) + " " +
+		strconv.FormatFloat(utm.Northing, 'f', 0, 64)
+}
+
+// utmZone returns zone number and latitude band at lat, lng in degrees,
+// with exceptions of Norway and Svalbard.
+func utmZone(lat, lng float64) (zone int, band byte, err error) {
+	if lat < -80 || lat > 84 {
+		return 0, 0, errors.New("UTM latitude out of range")
+	}
+	lng = math.Mod(lng+180, 360)
+	if lng < 0 {
+		lng += 360
+	}
+	zone = int(lng/6)%60 + 1
+	lng -= 180
+
+	b := int((lat + 80) / 8)
+	if b > len(utmBands)-1 {
+		b = len(utmBands) - 1 // X is 12 degrees.
+	}
+	band = utmBands[b]
+
+	if band == 'V' && 3 <= lng && lng < 12 {
+		zone = 32
+	} else if band == 'X' && 0 <= lng && lng < 42 {
+		switch {
+		case lng < 9:
+			zone = 31
+		case lng < 21:
+			zone = 33
+		case lng < 33:
+			zone = 35
+		default:
+			zone = 37
+		}
+	}
+	return
+}
+
+// utmCentralMeridian returns central meridian of zone in radian.
+func utmCentralMeridian(zone int) float64 {
+	return float64(zone*6-183) * math.Pi / 180
+}
+
+// UTM converts latlong to UTM. Latitude must be between 80S and 84N.
+func (latlong Point) UTM() (utm UTM, err error) {
+	lat, lng := latlong.Lat().Degrees(), latlong.Lng().Degrees()
+	if utm.Zone, utm.Band, err = utmZone(lat, lng); err != nil {
+		return
+	}
+	utm.Northing, utm.Easting, _, _ = utmGaussKruger.forward(
+		float64(latlong.Lat().S1Angle()), float64(latlong.Lng().S1Angle()), 0, utmCentralMeridian(utm.Zone))
+	utm.Easting += utmFalseEasting
+	if lat < 0 {
+		utm.Northing += utmFalseNorthingS
+	}
+	return
+}
+
+// Point converts UTM to Point on WGS84 with precision of 1m.
+func (utm UTM) Point() (latlong Point, err error) {
+	if utm.Zone < 1 || utm.Zone > 60 {
+		return latlong, errors.New("UTM zone error " + strconv.Itoa(utm.Zone))
+	}
+	if strings.IndexByte(utmBands, utm.Band) < 0 {
+		return latlong, errors.New("UTM band error " + string(utm.Band))
+	}
+	northing := utm.Northing
+	if utm.Band < 'N' {
+		northing -= utmFalseNorthingS
+	}
+	lat, lng, _, _ := utmGaussKruger.inverse(northing, utm.Easting-utmFalseEasting, 0, utmCentralMeridian(utm.Zone))
+	latlong = NewPoint(
+		NewAngleFromS1Angle(s1.Angle(lat), s1.Angle(1/WGS84.a)),
+		NewAngleFromS1Angle(s1.Angle(math.Remainder(lng, 2*math.Pi)), s1.Angle(1/WGS84.a/math.Cos(lat))),
+		nil)
+	latlong.datum = DatumWGS84
+	return
+}
+
+// mgrsLetters are letters of 100km square without I and O.
+const mgrsLetters = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// MGRS returns Military Grid Reference System string of latlong,
+// such as "54SUE8584" for digits 2.
+// digits is number of digits for each of easting and northing, 0 (100km) to 5 (1m).
+func (latlong Point) MGRS(digits int) (string, error) {
+	if digits < 0 || digits > 5 {
+		return "", errors.New("MGRS digits error " + strconv.Itoa(digits))
+	}
+	utm, err := latlong.UTM()
+	if err != nil {
+		return "", err
+	}
+
+	e := int(math.Floor(utm.Easting))
+	n := int(math.Floor(utm.Northing))
+
+	b := make([]byte, 0, 15)
+	b = appendDigits(b, int64(utm.Zone), 2)
+	b = append(b, utm.Band)
+	b = append(b, mgrsLetters[(utm.Zone-1)%3*8+e/100000-1])
+	rowoffset := 0
+	if utm.Zone%2 == 0 {
+		rowoffset = 5
+	}
+	b = append(b, mgrsLetters[:20][(n/100000+rowoffset)%20])
+
+	if digits > 0 {
+		unit := int(math.Pow10(5 - digits))
+		b = appendDigits(b, int64(e%100000/unit), digits)
+		b = appendDigits(b, int64(n%100000/unit), digits)
+	}
+	return string(b), nil
+}
+
+// MGRS returns Military Grid Reference System string.
+// The precision is chosen from the latitudinal size of rect,
+// and empty string is returned if rect is larger than 100km or out of range.
+func (rect *Rect) MGRS() string {
+	const floaterr = 1.1 // grid is rotated by meridian convergence.
+
+	size := float64(EarthArcFromAngle(rect.Size().Lat)) * 1000
+	digits := -1
+	for d := 0; d <= 5; d++ {
+		if math.Pow10(5-d)*floaterr < size {
+			break
+		}
+		digits = d
+	}
+	if digits < 0 {
+		return ""
+	}
+	s, err := rect.Center().MGRS(digits)
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+// parseMGRS returns UTM of south-west corner and size in meters.
+func parseMGRS(mgrs string) (utm UTM, size float64, err error) {
+	mgrs = strings.ToUpper(strings.ReplaceAll(mgrs, " ", ""))
+
+	i := 0
+	for i < len(mgrs) && i < 2 && '0' <= mgrs[i] && mgrs[i] <= '9' {
+		i++
+	}
+	if i == 0 {
+		return utm, 0, errors.New("MGRS zone error")
+	}
+	utm.Zone, _ = strconv.Atoi(mgrs[:i])
+	if utm.Zone < 1 || utm.Zone > 60 {
+		return utm, 0, errors.New("MGRS zone error")
+	}
+	if len(mgrs) < i+3 {
+		return utm, 0, errors.New("MGRS length error")
+	}
+	band := strings.IndexByte(utmBands, mgrs[i])
+	if band < 0 {
+		return utm, 0, errors.New("MGRS band error")
+	}
+	utm.Band = mgrs[i]
+
+	col := strings.IndexByte(mgrsLetters, mgrs[i+1]) - (utm.Zone-1)%3*8
+	if col < 0 || col > 7 {
+		return utm, 0, errors.New("MGRS column letter error")
+	}
+	row := strings.IndexByte(mgrsLetters[:20], mgrs[i+2])
+	if row < 0 {
+		return utm, 0, errors.New("MGRS row letter error")
+	}
+	if utm.Zone%2 == 0 {
+		row = (row + 15) % 20
+	}
+
+	numerical := mgrs[i+3:]
+	if len(numerical)%2 != 0 || len(numerical) > 10 || strings.Trim(numerical, "0123456789") != "" {
+		return utm, 0, errors.New("MGRS digits error")
+	}
+	digits := len(numerical) / 2
+	size = math.Pow10(5 - digits)
+	var e, n int
+	if digits > 0 {
+		if e, err = strconv.Atoi(numerical[:digits]); err != nil {
+			return
+		}
+		if n, err = strconv.Atoi(numerical[digits:]); err != nil {
+			return
+		}
+	}
+	utm.Easting = float64(col+1)*100000 + float64(e)*size
+
+	// northing is ambiguous by 2000km, resolved by band.
+	bandlat := float64(band*8-80) * math.Pi / 180
+	bandnorthing, _, _, _ := utmGaussKruger.forward(bandlat, 0, 0, 0)
+	if bandlat < 0 {
+		bandnorthing += utmFalseNorthingS
+	}
+	bandnorthing = math.Floor(bandnorthing/100000) * 100000
+	utm.Northing = float64(row)*100000 + float64(n)*size
+	for utm.Northing < bandnorthing {
+		utm.Northing += 2000000
+	}
+	return utm, size, nil
+}
+
+// NewRectMGRS is from Military Grid Reference System string such as "54SUE8584".
+// The rect bounds the grid square, so its size reflects the precision of mgrs.
+func NewRectMGRS(mgrs string) (*Rect, error) {
+	sw, size, err := parseMGRS(mgrs)
+	if err != nil {
+		return nil, err
+	}
+	rect := new(Rect)
+	rect.Rect = s2.EmptyRect()
+	for _, d := range [][2]float64{{0, 0}, {size, 0}, {0, size}, {size, size}} {
+		corner := sw
+		corner.Easting += d[0]
+		corner.Northing += d[1]
+		p, err := corner.Point()
+		if err != nil {
+			return nil, err
+		}
+		rect.Rect = rect.Rect.AddPoint(s2.LatLng{Lat: p.Lat().S1Angle(), Lng: p.Lng().S1Angle()})
+	}
+	return rect, nil
+}
@@ -0,0 +1,88 @@
+package latlong_test
+
+import (
+	"math"
+	"testing"
+
+	latlong "github.com/toyo/go-latlong"
+)
+
+func TestUTM(t *testing.T) {
+	// Eiffel Tower
+	p := latlong.NewPoint(latlong.NewAngle(48.8583, 0.0001), latlong.NewAngle(2.2945, 0.0001), nil)
+	utm, err := p.UTM()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if utm.Zone != 31 || utm.Band != 'U' || math.Abs(utm.Easting-448251) > 2 || math.Abs(utm.Northing-5411943) > 2 {
+		t.Errorf("was %v", utm)
+	}
+
+	p1, err := utm.Point()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := float64(p.DistanceEllipsoidKm(&p1)); d > 1e-6 {
+		t.Errorf("round trip error %v km", d)
+	}
+
+	tests := []struct {
+		lat, lng float64
+		zone     int
+		band     byte
+	}{
+		{60, 5, 32, 'V'},   // Norway
+		{78, 15, 33, 'X'},  // Svalbard
+		{78, 8.9, 31, 'X'}, // Svalbard
+		{-33.9, 151.2, 56, 'H'},
+		{0, -180, 1, 'N'},
+	}
+	for _, tt := range tests {
+		utm, err := latlong.NewPoint(latlong.NewAngle(tt.lat, 0), latlong.NewAngle(tt.lng, 0), nil).UTM()
+		if err != nil || utm.Zone != tt.zone || utm.Band != tt.band {
+			t.Errorf("%v %v: expected %d%c, was %v %v", tt.lat, tt.lng, tt.zone, tt.band, utm, err)
+		}
+	}
+
+	if _, err := latlong.NewPoint(latlong.NewAngle(85, 0), latlong.NewAngle(0, 0), nil).UTM(); err == nil {
+		t.Error("expected out of range error")
+	}
+}
+
+func TestMGRS(t *testing.T) {
+	p := latlong.NewPoint(latlong.NewAngle(48.8583, 0.0001), latlong.NewAngle(2.2945, 0.0001), nil)
+	for digits, expct := range []string{"31UDQ", "31UDQ41", "31UDQ4811", "31UDQ482119", "31UDQ48251194", "31UDQ4825111943"} {
+		s, err := p.MGRS(digits)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s != expct {
+			t.Errorf("expected %s, was %s", expct, s)
+		}
+	}
+
+	for _, s := range []string{"31UDQ48251194", "56HLH3474884745", "33XWG", "18GVR93", "01NBA0000000000"} {
+		rect, err := latlong.NewRectMGRS(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+		if rect.MGRS() != s {
+			t.Errorf("expected %s, was %s", s, rect.MGRS())
+		}
+	}
+
+	rect, _ := latlong.NewRectMGRS("31UDQ48251194")
+	if !rect.ContainsPoint(p.S2Point()) {
+		t.Errorf("%v does not contain %v", rect, p)
+	}
+	if size := float64(latlong.EarthArcFromAngle(rect.Size().Lat)); size < 0.01 || size > 0.012 {
+		t.Errorf("size %v km", size)
+	}
+
+	for _, s := range []string{"", "61UDQ", "31IDQ", "31UIQ", "31UDW", "31UDQ123", "31UDQ+1+2", "31UDQ-1-2"} {
+		if _, err := latlong.NewRectMGRS(s); err == nil {
+			t.Errorf("%s: expected error", s)
+		}
+	}
+}
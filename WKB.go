@@ -0,0 +1,568 @@
+package latlong
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"math"
+	"strconv"
+)
+
+// Geometry types of WKB.
+const (
+	wkbPoint              = 1
+	wkbLineString         = 2
+	wkbPolygon            = 3
+	wkbMultiPoint         = 4
+	wkbMultiLineString    = 5
+	wkbMultiPolygon       = 6
+	wkbGeometryCollection = 7
+
+	ewkbZ    = 0x80000000
+	ewkbM    = 0x40000000
+	ewkbSRID = 0x20000000
+)
+
+// MarshalWKB returns ISO Well-Known Binary of g in order.
+// Circle and nil are not supported.
+func MarshalWKB(g Geometry, order binary.ByteOrder) ([]byte, error) {
+	w := wkbWriter{order: order}
+	if err := w.geometry(g, 0); err != nil {
+		return nil, err
+	}
+	return w.b, nil
+}
+
+// MarshalEWKB returns Extended Well-Known Binary of PostGIS in order.
+// SRID is omitted if srid is 0.
+func MarshalEWKB(g Geometry, srid int, order binary.ByteOrder) ([]byte, error) {
+	w := wkbWriter{order: order, ewkb: true}
+	if err := w.geometry(g, srid); err != nil {
+		return nil, err
+	}
+	return w.b, nil
+}
+
+type wkbWriter struct {
+	b     []byte
+	order binary.ByteOrder
+	ewkb  bool
+}
+
+func (w *wkbWriter) uint32(v uint32) {
+	var b [4]byte
+	w.order.PutUint32(b[:], v)
+	w.b = append(w.b, b[:]...)
+}
+
+func (w *wkbWriter) float64(v float64) {
+	var b [8]byte
+	w.order.PutUint64(b[:], math.Float64bits(v))
+	w.b = append(w.b, b[:]...)
+}
+
+func (w *wkbWriter) header(typ uint32, z bool, srid int) {
+	if w.order == binary.LittleEndian {
+		w.b = append(w.b, 1)
+	} else {
+		w.b = append(w.b, 0)
+	}
+	switch {
+	case w.ewkb && z:
+		typ |= ewkbZ
+	case z:
+		typ += 1000
+	}
+	if w.ewkb && srid != 0 {
+		w.uint32(typ | ewkbSRID)
+		w.uint32(uint32(srid))
+		return
+	}
+	w.uint32(typ)
+}
+
+func (w *wkbWriter) coord(latlong Point, z bool) {
+	w.float64(roundDegrees(latlong.lng.Degrees()))
+	w.float64(roundDegrees(latlong.lat.Degrees()))
+	if z {
+		w.float64(*latlong.alt)
+	}
+}
+
+func (w *wkbWriter) coords(cds MultiPoint, z bool, ring bool) {
+	closed := !ring || len(cds) == 0 || (cds[0].lat == cds[len(cds)-1].lat && cds[0].lng == cds[len(cds)-1].lng)
+	if closed {
+		w.uint32(uint32(len(cds)))
+	} else {
+		w.uint32(uint32(len(cds) + 1))
+	}
+	for i := range cds {
+		w.coord(cds[i], z)
+	}
+	if !closed {
+		w.coord(cds[0], z)
+	}
+}
+
+func (w *wkbWriter) rings(cds Polygon, z bool) {
+	if len(cds.MultiPoint) == 0 {
+		w.uint32(0)
+		return
+	}
+	w.uint32(uint32(1 + len(cds.Holes)))
+	w.coords(cds.MultiPoint, z, true)
+	for _, h := range cds.Holes {
+		w.coords(h.MultiPoint, z, true)
+	}
+}
+
+// geometry writes g with srid only on the top level.
+func (w *wkbWriter) geometry(g Geometry, srid int) error {
+	switch g := g.(type) {
+	case Point:
+		z := g.alt != nil
+		w.header(wkbPoint, z, srid)
+		w.coord(g, z)
+	case MultiPoint:
+		z := wktHasZ(g)
+		w.header(wkbMultiPoint, z, srid)
+		w.uint32(uint32(len(g)))
+		for i := range g {
+			w.header(wkbPoint, z, 0)
+			w.coord(g[i], z)
+		}
+	case LineString:
+		z := wktHasZ(g.MultiPoint)
+		w.header(wkbLineString, z, srid)
+		w.coords(g.MultiPoint, z, false)
+	case Polygon:
+		z := wktPolygonHasZ(g)
+		w.header(wkbPolygon, z, srid)
+		w.rings(g, z)
+	case MultiLineString:
+		z := len(g) > 0
+		for i := range g {
+			z = z && wktHasZ(g[i].MultiPoint)
+		}
+		w.header(wkbMultiLineString, z, srid)
+		w.uint32(uint32(len(g)))
+		for i := range g {
+			w.header(wkbLineString, z, 0)
+			w.coords(g[i].MultiPoint, z, false)
+		}
+	case MultiPolygon:
+		z := len(g) > 0
+		for i := range g {
+			z = z && wktPolygonHasZ(g[i])
+		}
+		w.header(wkbMultiPolygon, z, srid)
+		w.uint32(uint32(len(g)))
+		for i := range g {
+			w.header(wkbPolygon, z, 0)
+			w.rings(g[i], z)
+		}
+	case GeometryCollection:
+		w.header(wkbGeometryCollection, false, srid)
+		w.uint32(uint32(len(g)))
+		for i := range g {
+			if err := w.geometry(g[i], 0); err != nil {
+				return err
+			}
+		}
+	case nil:
+		return errors.New("WKB nil geometry")
+	default:
+		return errors.New("WKB unsupported type " + g.Type())
+	}
+	return nil
+}
+
+// ParseWKB parses Well-Known Binary (ISO or OGC) or Extended Well-Known Binary of PostGIS.
+// srid is 0 if not specified. Points are tagged by datum if srid is known.
+// M values are ignored.
+func ParseWKB(wkb []byte) (g Geometry, srid int, err error) {
+	r := wkbReader{b: wkb}
+	if g, err = r.geometry(0); err != nil {
+		return nil, 0, err
+	}
+	if r.i != len(r.b) {
+		return nil, 0, r.errorf("trailing bytes")
+	}
+	return g, r.srid, nil
+}
+
+type wkbReader struct {
+	b     []byte
+	i     int
+	order binary.ByteOrder
+	srid  int
+	datum Datum
+}
+
+func (r *wkbReader) errorf(msg string) error {
+	return errors.New("WKB " + msg + " at offset " + strconv.Itoa(r.i))
+}
+
+func (r *wkbReader) uint32() (uint32, error) {
+	if r.i+4 > len(r.b) {
+		return 0, r.errorf("unexpected end")
+	}
+	v := r.order.Uint32(r.b[r.i:])
+	r.i += 4
+	return v, nil
+}
+
+// count reads number of elements which are at least size bytes each.
+func (r *wkbReader) count(size int) (int, error) {
+	n, err := r.uint32()
+	if err != nil {
+		return 0, err
+	}
+	if uint64(n)*uint64(size) > uint64(len(r.b)-r.i) {
+		return 0, r.errorf("count error")
+	}
+	return int(n), nil
+}
+
+// header returns geometry type and number of ordinates.
+func (r *wkbReader) header() (typ uint32, dims int, z bool, err error) {
+	if r.i >= len(r.b) {
+		return 0, 0, false, r.errorf("unexpected end")
+	}
+	switch r.b[r.i] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return 0, 0, false, r.errorf("byte order error")
+	}
+	r.i++
+	if typ, err = r.uint32(); err != nil {
+		return
+	}
+
+	dims = 2
+	if typ&ewkbZ != 0 {
+		dims, z = dims+1, true
+	}
+	if typ&ewkbM != 0 {
+		dims++
+	}
+	if typ&ewkbSRID != 0 {
+		srid, err := r.uint32()
+		if err != nil {
+			return 0, 0, false, err
+		}
+		r.srid = int(srid)
+		r.datum = datumFromCRS("EPSG:" + strconv.Itoa(r.srid))
+	}
+	typ &^= ewkbZ | ewkbM | ewkbSRID
+	switch typ / 1000 {
+	case 1:
+		dims, z = dims+1, true
+	case 2:
+		dims++
+	case 3:
+		dims, z = dims+2, true
+	}
+	if dims > 4 {
+		return 0, 0, false, r.errorf("dimension error")
+	}
+	return typ % 1000, dims, z, nil
+}
+
+func (r *wkbReader) coord(dims int, z bool) (latlong Point, err error) {
+	if r.i+8*dims > len(r.b) {
+		return latlong, r.errorf("unexpected end")
+	}
+	var ords [4]float64
+	for j := 0; j < dims; j++ {
+		ords[j] = math.Float64frombits(r.order.Uint64(r.b[r.i:]))
+		r.i += 8
+	}
+	if math.IsNaN(ords[0]) || math.IsNaN(ords[1]) {
+		return latlong, r.errorf("POINT EMPTY is not supported")
+	}
+	if math.Abs(ords[1]) > 90 || math.Abs(ords[0]) > 180 {
+		return latlong, r.errorf("coordinate range error")
+	}
+	latlong.lng = angleFromFloat(ords[0])
+	latlong.lat = angleFromFloat(ords[1])
+	if z {
+		altitude := ords[2]
+		latlong.alt = &altitude
+	}
+	latlong.datum = r.datum
+	return
+}
+
+// roundDegrees rounds deg to 15 significant digits not to show error of radian.
+func roundDegrees(deg float64) float64 {
+	deg, _ = strconv.ParseFloat(strconv.FormatFloat(deg, 'g', 15, 64), 64)
+	return deg
+}
+
+// angleFromFloat returns Angle of deg with precision of the shortest decimal representation.
+func angleFromFloat(deg float64) Angle {
+	return AngleFromBytes(strconv.AppendFloat(nil, roundDegrees(deg), 'f', -1, 64))
+}
+
+func (r *wkbReader) coords(dims int, z bool) (cds MultiPoint, err error) {
+	n, err := r.count(8 * dims)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	cds = make(MultiPoint, n)
+	for i := range cds {
+		if cds[i], err = r.coord(dims, z); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+func (r *wkbReader) polygon(dims int, z bool) (pg Polygon, err error) {
+	n, err := r.count(4)
+	if err != nil {
+		return
+	}
+	for i := 0; i < n; i++ {
+		cds, err := r.coords(dims, z)
+		if err != nil {
+			return pg, err
+		}
+		if i == 0 {
+			pg.MultiPoint = cds
+		} else {
+			pg.Holes = append(pg.Holes, LineString{MultiPoint: cds})
+		}
+	}
+	return
+}
+
+// geometry reads a geometry. expect is expected type or 0 for any.
+func (r *wkbReader) geometry(expect uint32) (Geometry, error) {
+	start := r.i
+	typ, dims, z, err := r.header()
+	if err != nil {
+		return nil, err
+	}
+	if expect != 0 && typ != expect {
+		r.i = start
+		return nil, r.errorf("unexpected type " + strconv.Itoa(int(typ)))
+	}
+
+	switch typ {
+	case wkbPoint:
+		return r.coord(dims, z)
+	case wkbLineString:
+		cds, err := r.coords(dims, z)
+		return LineString{MultiPoint: cds}, err
+	case wkbPolygon:
+		return r.polygon(dims, z)
+	case wkbMultiPoint:
+		n, err := r.count(5)
+		if err != nil {
+			return nil, err
+		}
+		cds := make(MultiPoint, n)
+		for i := range cds {
+			g, err := r.geometry(wkbPoint)
+			if err != nil {
+				return nil, err
+			}
+			cds[i] = g.(Point)
+		}
+		return cds, nil
+	case wkbMultiLineString:
+		n, err := r.count(5)
+		if err != nil {
+			return nil, err
+		}
+		mls := make(MultiLineString, n)
+		for i := range mls {
+			g, err := r.geometry(wkbLineString)
+			if err != nil {
+				return nil, err
+			}
+			mls[i] = g.(LineString)
+		}
+		return mls, nil
+	case wkbMultiPolygon:
+		n, err := r.count(5)
+		if err != nil {
+			return nil, err
+		}
+		mp := make(MultiPolygon, n)
+		for i := range mp {
+			g, err := r.geometry(wkbPolygon)
+			if err != nil {
+				return nil, err
+			}
+			mp[i] = g.(Polygon)
+		}
+		return mp, nil
+	case wkbGeometryCollection:
+		n, err := r.count(5)
+		if err != nil {
+			return nil, err
+		}
+		gc := make(GeometryCollection, n)
+		for i := range gc {
+			if gc[i], err = r.geometry(0); err != nil {
+				return nil, err
+			}
+		}
+		return gc, nil
+	}
+	r.i = start
+	return nil, r.errorf("unknown type " + strconv.Itoa(int(typ)))
+}
+
+// scanGeometry parses src of database as WKB, EWKB, hex string of them or WKT.
+func scanGeometry(src interface{}) (Geometry, error) {
+	var b []byte
+	switch src := src.(type) {
+	case []byte:
+		b = src
+	case string:
+		b = []byte(src)
+	case nil:
+		return nil, nil
+	default:
+		return nil, errors.New("Unsupported type for geometry")
+	}
+	if len(b) == 0 {
+		return nil, errors.New("Empty geometry")
+	}
+	if b[0] == 0 || b[0] == 1 {
+		g, _, err := ParseWKB(b)
+		return g, err
+	}
+	if (b[0] == '0' || b[0] == '1') && len(b)%2 == 0 {
+		wkb := make([]byte, len(b)/2)
+		if _, err := hex.Decode(wkb, b); err == nil {
+			g, _, err := ParseWKB(wkb)
+			return g, err
+		}
+	}
+	g, _, err := ParseWKT(string(b))
+	return g, err
+}
+
+// valueGeometry returns EWKB with SRID of datum of the first point.
+func valueGeometry(g Geometry) (driver.Value, error) {
+	var srid int
+	if cds := geometryPoints(g); len(cds) > 0 {
+		srid = cds[0].datum.EPSG()
+	}
+	return MarshalEWKB(g, srid, binary.LittleEndian)
+}
+
+// Scan is for sql.Scanner.
+func (latlong *Point) Scan(src interface{}) error {
+	g, err := scanGeometry(src)
+	if err != nil {
+		return err
+	}
+	p, ok := g.(Point)
+	if !ok {
+		return errors.New("Geometry is not Point")
+	}
+	*latlong = p
+	return nil
+}
+
+// Value is for driver.Valuer. It returns EWKB.
+func (latlong Point) Value() (driver.Value, error) {
+	return valueGeometry(latlong)
+}
+
+// Scan is for sql.Scanner.
+func (cds *LineString) Scan(src interface{}) error {
+	g, err := scanGeometry(src)
+	if err != nil {
+		return err
+	}
+	ls, ok := g.(LineString)
+	if !ok {
+		return errors.New("Geometry is not LineString")
+	}
+	*cds = ls
+	return nil
+}
+
+// Value is for driver.Valuer. It returns EWKB.
+func (cds LineString) Value() (driver.Value, error) {
+	return valueGeometry(cds)
+}
+
+// Scan is for sql.Scanner.
+func (cds *Polygon) Scan(src interface{}) error {
+	g, err := scanGeometry(src)
+	if err != nil {
+		return err
+	}
+	pg, ok := g.(Polygon)
+	if !ok {
+		return errors.New("Geometry is not Polygon")
+	}
+	*cds = pg
+	return nil
+}
+
+// Value is for driver.Valuer. It returns EWKB.
+func (cds Polygon) Value() (driver.Value, error) {
+	return valueGeometry(cds)
+}
+
+// Scan is for sql.Scanner. NULL is scanned as nil geometry.
+func (geom *GeoJSONGeometry) Scan(src interface{}) (err error) {
+	geom.geo, err = scanGeometry(src)
+	return
+}
+
+// Value is for driver.Valuer. It returns EWKB, or NULL for nil geometry.
+func (geom GeoJSONGeometry) Value() (driver.Value, error) {
+	if geom.geo == nil {
+		return nil, nil
+	}
+	return valueGeometry(geom.geo)
+}
+
+// geometryPoints returns points of g.
+func geometryPoints(g Geometry) (cds MultiPoint) {
+	switch g := g.(type) {
+	case Point:
+		return MultiPoint{g}
+	case MultiPoint:
+		return g
+	case LineString:
+		return g.MultiPoint
+	case Polygon:
+		cds = append(cds, g.MultiPoint...)
+		for _, h := range g.Holes {
+			cds = append(cds, h.MultiPoint...)
+		}
+	case MultiLineString:
+		for i := range g {
+			cds = append(cds, g[i].MultiPoint...)
+		}
+	case MultiPolygon:
+		for i := range g {
+			cds = append(cds, geometryPoints(g[i])...)
+		}
+	case GeometryCollection:
+		for i := range g {
+			cds = append(cds, geometryPoints(g[i])...)
+		}
+	case Circle:
+		return MultiPoint{g.Point}
+	}
+	return
+}
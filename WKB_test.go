@@ -0,0 +1,140 @@
+package latlong_test
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	latlong "github.com/toyo/go-latlong"
+)
+
+func TestWKB(t *testing.T) {
+	for _, wkt := range []string{
+		`POINT (139.7671 35.6812)`,
+		`POINT Z (139.7 35.6 -10.5)`,
+		`MULTIPOINT ((10 40), (40 30))`,
+		`LINESTRING Z (100 0 1, 101 1 2)`,
+		`POLYGON ((30 10, 40 40, 20 40, 10 20, 30 10), (20 30, 35 35, 30 20, 20 30))`,
+		`MULTILINESTRING ((100 0, 101 1), (102 2, 103 3))`,
+		`MULTIPOLYGON (((102 2, 103 2, 103 3, 102 3, 102 2)), ((100 0, 101 0, 101 1, 100 1, 100 0)))`,
+		`GEOMETRYCOLLECTION (POINT (100 0), LINESTRING (101 0, 102 1))`,
+	} {
+		g, _, err := latlong.ParseWKT(wkt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+			for _, srid := range []int{0, 4326} {
+				var b []byte
+				if srid == 0 {
+					b, err = latlong.MarshalWKB(g, order)
+				} else {
+					b, err = latlong.MarshalEWKB(g, srid, order)
+				}
+				if err != nil {
+					t.Errorf("%s: %v", wkt, err)
+					continue
+				}
+				g1, srid1, err := latlong.ParseWKB(b)
+				if err != nil {
+					t.Errorf("%s %x: %v", wkt, b, err)
+					continue
+				}
+				if s, _ := latlong.MarshalWKT(g1); string(s) != wkt || srid1 != srid {
+					t.Errorf("expected %s %d, was %s %d", wkt, srid, s, srid1)
+				}
+			}
+		}
+	}
+
+	// PostGIS: SELECT ST_AsEWKB('SRID=4326;POINT(1 2)'::geometry)
+	b, _ := hex.DecodeString("0101000020E6100000000000000000F03F0000000000000040")
+	g, srid, err := latlong.ParseWKB(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, ok := g.(latlong.Point); !ok || srid != 4326 || p.Lng().Degrees() != 1 || p.Lat().Degrees() != 2 || p.Datum() != latlong.DatumWGS84 {
+		t.Errorf("was %v %d", g, srid)
+	}
+
+	for _, s := range []string{"", "02", "0101000000000000000000F03F", "0109000000", "0104000000FFFFFFFF",
+		"010100000000000000006061400000000000C05740", "01010000000000000000407F400000000000804140"} {
+		b, _ := hex.DecodeString(s)
+		if _, _, err := latlong.ParseWKB(b); err == nil {
+			t.Errorf("%s: expected error", s)
+		}
+	}
+}
+
+func TestWKBHoleWithoutZ(t *testing.T) {
+	var pg latlong.Polygon
+	if err := json.Unmarshal([]byte(`[[[30,10,1],[40,40,1],[20,40,1],[30,10,1]],[[25,30],[30,35],[28,25],[25,30]]]`), &pg); err != nil {
+		t.Fatal(err)
+	}
+	for _, g := range []latlong.Geometry{pg, latlong.MultiPolygon{pg}} {
+		b, err := latlong.MarshalWKB(g, binary.LittleEndian)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g1, _, err := latlong.ParseWKB(b)
+		if s, _ := latlong.MarshalWKT(g1); err != nil || (string(s) != `POLYGON ((30 10, 40 40, 20 40, 30 10), (25 30, 30 35, 28 25, 25 30))` &&
+			string(s) != `MULTIPOLYGON (((30 10, 40 40, 20 40, 30 10), (25 30, 30 35, 28 25, 25 30)))`) {
+			t.Errorf("was %s %v", s, err)
+		}
+	}
+}
+
+func TestWKBSQL(t *testing.T) {
+	p := latlong.NewPoint(latlong.NewAngle(35.6812, 0.0001), latlong.NewAngle(139.7671, 0.0001), nil).WithDatum(latlong.DatumJGD2011)
+	v, err := p.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var p1 latlong.Point
+	if err := p1.Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	if !p.Equal(p1) {
+		t.Errorf("expected %v, was %v", p, p1)
+	}
+
+	// hex string as PostGIS text format
+	if err := p1.Scan(hex.EncodeToString(v.([]byte))); err != nil || !p.Equal(p1) {
+		t.Errorf("expected %v, was %v %v", p, p1, err)
+	}
+
+	var ls latlong.LineString
+	if err := ls.Scan([]byte(`LINESTRING (100 0, 101 1)`)); err != nil || len(ls.MultiPoint) != 2 {
+		t.Errorf("was %v %v", ls, err)
+	}
+	if err := ls.Scan(v); err == nil {
+		t.Error("expected type error")
+	}
+	var p2 latlong.Point
+	if b, _ := hex.DecodeString("010100000000000000006061400000000000C05740"); p2.Scan(b) == nil {
+		t.Error("expected range error")
+	}
+
+	var geom latlong.GeoJSONGeometry
+	if err := json.Unmarshal([]byte(`{"type":"Polygon","coordinates":[[[100,0],[101,0],[101,1],[100,1],[100,0]]]}`), &geom); err != nil {
+		t.Fatal(err)
+	}
+	v, err = geom.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pg latlong.Polygon
+	if err := pg.Scan(v); err != nil || !geom.Geo().Equal(pg) {
+		t.Errorf("expected %v, was %v %v", geom, pg, err)
+	}
+
+	var geom1 latlong.GeoJSONGeometry
+	if err := geom1.Scan(nil); err != nil || geom1.Geo() != nil {
+		t.Errorf("was %v %v", geom1, err)
+	}
+	if err := geom1.Scan(v); err != nil || !geom.Equal(geom1) {
+		t.Errorf("expected %v, was %v %v", geom, geom1, err)
+	}
+}
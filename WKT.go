@@ -0,0 +1,521 @@
+package latlong
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// WKT returns Well-Known Text such as "POINT (139.7 35.6)".
+func (latlong Point) WKT() string {
+	b, _ := appendWKT(nil, latlong)
+	return string(b)
+}
+
+// WKT returns Well-Known Text.
+func (cds MultiPoint) WKT() string {
+	b, _ := appendWKT(nil, cds)
+	return string(b)
+}
+
+// WKT returns Well-Known Text.
+func (cds LineString) WKT() string {
+	b, _ := appendWKT(nil, cds)
+	return string(b)
+}
+
+// WKT returns Well-Known Text.
+func (cds Polygon) WKT() string {
+	b, _ := appendWKT(nil, cds)
+	return string(b)
+}
+
+// WKT returns Well-Known Text.
+func (cds MultiLineString) WKT() string {
+	b, _ := appendWKT(nil, cds)
+	return string(b)
+}
+
+// WKT returns Well-Known Text.
+func (cds MultiPolygon) WKT() string {
+	b, _ := appendWKT(nil, cds)
+	return string(b)
+}
+
+// WKT returns Well-Known Text.
+func (gc GeometryCollection) WKT() string {
+	b, _ := appendWKT(nil, gc)
+	return string(b)
+}
+
+// WKT returns Well-Known Text of POLYGON.
+func (rect *Rect) WKT() string {
+	return rect.Polygon().WKT()
+}
+
+// EWKT returns Extended Well-Known Text of POLYGON. SRID is omitted if srid is 0.
+func (rect *Rect) EWKT(srid int) string {
+	b, _ := MarshalEWKT(rect.Polygon(), srid)
+	return string(b)
+}
+
+// MarshalWKT returns Well-Known Text of g.
+// Circle and nil are not supported.
+func MarshalWKT(g Geometry) ([]byte, error) {
+	return appendWKT(nil, g)
+}
+
+// MarshalEWKT returns Extended Well-Known Text of PostGIS such as "SRID=4326;POINT (139.7 35.6)".
+// SRID is omitted if srid is 0.
+func MarshalEWKT(g Geometry, srid int) ([]byte, error) {
+	var b []byte
+	if srid != 0 {
+		b = append(b, `SRID=`...)
+		b = strconv.AppendInt(b, int64(srid), 10)
+		b = append(b, ';')
+	}
+	return appendWKT(b, g)
+}
+
+func appendWKT(b []byte, g Geometry) ([]byte, error) {
+	switch g := g.(type) {
+	case Point:
+		z := g.alt != nil
+		b = appendWKTTag(b, "POINT", z)
+		b = append(b, '(')
+		b = appendWKTCoord(b, g, z)
+		return append(b, ')'), nil
+	case MultiPoint:
+		z := wktHasZ(g)
+		b = appendWKTTag(b, "MULTIPOINT", z)
+		if len(g) == 0 {
+			return append(b, `EMPTY`...), nil
+		}
+		b = append(b, '(')
+		for i := range g {
+			if i > 0 {
+				b = append(b, `, `...)
+			}
+			b = append(b, '(')
+			b = appendWKTCoord(b, g[i], z)
+			b = append(b, ')')
+		}
+		return append(b, ')'), nil
+	case LineString:
+		z := wktHasZ(g.MultiPoint)
+		b = appendWKTTag(b, "LINESTRING", z)
+		return appendWKTCoords(b, g.MultiPoint, z, false), nil
+	case Polygon:
+		z := wktPolygonHasZ(g)
+		b = appendWKTTag(b, "POLYGON", z)
+		return appendWKTRings(b, g, z), nil
+	case MultiLineString:
+		z := len(g) > 0
+		for i := range g {
+			z = z && wktHasZ(g[i].MultiPoint)
+		}
+		b = appendWKTTag(b, "MULTILINESTRING", z)
+		if len(g) == 0 {
+			return append(b, `EMPTY`...), nil
+		}
+		b = append(b, '(')
+		for i := range g {
+			if i > 0 {
+				b = append(b, `, `...)
+			}
+			b = appendWKTCoords(b, g[i].MultiPoint, z, false)
+		}
+		return append(b, ')'), nil
+	case MultiPolygon:
+		z := len(g) > 0
+		for i := range g {
+			z = z && wktPolygonHasZ(g[i])
+		}
+		b = appendWKTTag(b, "MULTIPOLYGON", z)
+		if len(g) == 0 {
+			return append(b, `EMPTY`...), nil
+		}
+		b = append(b, '(')
+		for i := range g {
+			if i > 0 {
+				b = append(b, `, `...)
+			}
+			b = appendWKTRings(b, g[i], z)
+		}
+		return append(b, ')'), nil
+	case GeometryCollection:
+		b = append(b, `GEOMETRYCOLLECTION `...)
+		if len(g) == 0 {
+			return append(b, `EMPTY`...), nil
+		}
+		b = append(b, '(')
+		for i := range g {
+			if i > 0 {
+				b = append(b, `, `...)
+			}
+			var err error
+			if b, err = appendWKT(b, g[i]); err != nil {
+				return nil, err
+			}
+		}
+		return append(b, ')'), nil
+	case nil:
+		return nil, errors.New("WKT nil geometry")
+	}
+	return nil, errors.New("WKT unsupported type " + g.Type())
+}
+
+func appendWKTTag(b []byte, tag string, z bool) []byte {
+	b = append(b, tag...)
+	if z {
+		b = append(b, ` Z`...)
+	}
+	return append(b, ' ')
+}
+
+func appendWKTCoord(b []byte, latlong Point, z bool) []byte {
+	b = append(b, latlong.lng.String()...)
+	b = append(b, ' ')
+	b = append(b, latlong.lat.String()...)
+	if z {
+		b = append(b, ' ')
+		b = strconv.AppendFloat(b, *latlong.alt, 'f', -1, 64)
+	}
+	return b
+}
+
+// appendWKTCoords appends "(x y, x y)". The ring is closed if ring is true.
+func appendWKTCoords(b []byte, cds MultiPoint, z bool, ring bool) []byte {
+	if len(cds) == 0 {
+		return append(b, `EMPTY`...)
+	}
+	b = append(b, '(')
+	for i := range cds {
+		if i > 0 {
+			b = append(b, `, `...)
+		}
+		b = appendWKTCoord(b, cds[i], z)
+	}
+	if ring && (cds[0].lat != cds[len(cds)-1].lat || cds[0].lng != cds[len(cds)-1].lng) {
+		b = append(b, `, `...)
+		b = appendWKTCoord(b, cds[0], z)
+	}
+	return append(b, ')')
+}
+
+func appendWKTRings(b []byte, cds Polygon, z bool) []byte {
+	if len(cds.MultiPoint) == 0 {
+		return append(b, `EMPTY`...)
+	}
+	b = append(b, '(')
+	b = appendWKTCoords(b, cds.MultiPoint, z, true)
+	for _, h := range cds.Holes {
+		b = append(b, `, `...)
+		b = appendWKTCoords(b, h.MultiPoint, z, true)
+	}
+	return append(b, ')')
+}
+
+// wktHasZ is true if all points have altitude.
+func wktHasZ(cds MultiPoint) bool {
+	for i := range cds {
+		if cds[i].alt == nil {
+			return false
+		}
+	}
+	return len(cds) > 0
+}
+
+// wktPolygonHasZ is true if all points of outer ring and holes have altitude.
+func wktPolygonHasZ(g Polygon) bool {
+	z := wktHasZ(g.MultiPoint)
+	for _, h := range g.Holes {
+		z = z && wktHasZ(h.MultiPoint)
+	}
+	return z
+}
+
+// ParseWKT parses Well-Known Text or Extended Well-Known Text of PostGIS.
+// srid is 0 for WKT. Points are tagged by datum if srid is known, such as 4326 for WGS84.
+// M values are ignored.
+func ParseWKT(wkt string) (g Geometry, srid int, err error) {
+	p := wktParser{s: wkt}
+	p.skipSpace()
+	if strings.HasPrefix(strings.ToUpper(p.s[p.i:]), "SRID=") {
+		p.i += len("SRID=")
+		start := p.i
+		for p.i < len(p.s) && '0' <= p.s[p.i] && p.s[p.i] <= '9' {
+			p.i++
+		}
+		if srid, err = strconv.Atoi(p.s[start:p.i]); err != nil {
+			return nil, 0, p.errorf("SRID error")
+		}
+		if err = p.expect(';'); err != nil {
+			return
+		}
+		p.datum = datumFromCRS("EPSG:" + strconv.Itoa(srid))
+	}
+	if g, err = p.geometry(); err != nil {
+		return nil, 0, err
+	}
+	p.skipSpace()
+	if p.i != len(p.s) {
+		return nil, 0, p.errorf("trailing characters")
+	}
+	return
+}
+
+type wktParser struct {
+	s     string
+	i     int
+	dims  int // number of ordinates, 0 if unknown.
+	z     bool
+	datum Datum
+}
+
+func (p *wktParser) errorf(msg string) error {
+	return errors.New("WKT " + msg + " at offset " + strconv.Itoa(p.i))
+}
+
+func (p *wktParser) skipSpace() {
+	for p.i < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.i]) >= 0 {
+		p.i++
+	}
+}
+
+func (p *wktParser) word() string {
+	p.skipSpace()
+	start := p.i
+	for p.i < len(p.s) && ('A' <= p.s[p.i]&^0x20 && p.s[p.i]&^0x20 <= 'Z') {
+		p.i++
+	}
+	return strings.ToUpper(p.s[start:p.i])
+}
+
+func (p *wktParser) expect(c byte) error {
+	p.skipSpace()
+	if p.i >= len(p.s) || p.s[p.i] != c {
+		return p.errorf("expected '" + string(c) + "'")
+	}
+	p.i++
+	return nil
+}
+
+// next reports whether next character is c and skips it.
+func (p *wktParser) next(c byte) bool {
+	p.skipSpace()
+	if p.i < len(p.s) && p.s[p.i] == c {
+		p.i++
+		return true
+	}
+	return false
+}
+
+// empty reports whether EMPTY follows, otherwise expects '('.
+func (p *wktParser) empty() (bool, error) {
+	p.skipSpace()
+	save := p.i
+	if p.word() == "EMPTY" {
+		return true, nil
+	}
+	p.i = save
+	return false, p.expect('(')
+}
+
+func (p *wktParser) geometry() (Geometry, error) {
+	tag := p.word()
+	save := p.i
+	switch p.word() {
+	case "Z":
+		p.dims, p.z = 3, true
+	case "M":
+		p.dims, p.z = 3, false
+	case "ZM":
+		p.dims, p.z = 4, true
+	default:
+		p.i = save
+		p.dims, p.z = 0, false
+		// such as POINTZ
+		if strings.HasSuffix(tag, "ZM") {
+			tag, p.dims, p.z = strings.TrimSuffix(tag, "ZM"), 4, true
+		} else if strings.HasSuffix(tag, "Z") {
+			tag, p.dims, p.z = strings.TrimSuffix(tag, "Z"), 3, true
+		} else if strings.HasSuffix(tag, "M") {
+			tag, p.dims, p.z = strings.TrimSuffix(tag, "M"), 3, false
+		}
+	}
+
+	switch tag {
+	case "POINT":
+		if empty, err := p.empty(); err != nil || empty {
+			if err == nil {
+				err = p.errorf("POINT EMPTY is not supported")
+			}
+			return nil, err
+		}
+		latlong, err := p.coord()
+		if err != nil {
+			return nil, err
+		}
+		return latlong, p.expect(')')
+	case "MULTIPOINT":
+		if empty, err := p.empty(); err != nil || empty {
+			return MultiPoint{}, err
+		}
+		var cds MultiPoint
+		for {
+			paren := p.next('(')
+			latlong, err := p.coord()
+			if err != nil {
+				return nil, err
+			}
+			if paren {
+				if err := p.expect(')'); err != nil {
+					return nil, err
+				}
+			}
+			cds = append(cds, latlong)
+			if !p.next(',') {
+				break
+			}
+		}
+		return cds, p.expect(')')
+	case "LINESTRING":
+		cds, err := p.coords()
+		return LineString{MultiPoint: cds}, err
+	case "POLYGON":
+		return p.polygon()
+	case "MULTILINESTRING":
+		if empty, err := p.empty(); err != nil || empty {
+			return MultiLineString{}, err
+		}
+		var mls MultiLineString
+		for {
+			cds, err := p.coords()
+			if err != nil {
+				return nil, err
+			}
+			mls = append(mls, LineString{MultiPoint: cds})
+			if !p.next(',') {
+				break
+			}
+		}
+		return mls, p.expect(')')
+	case "MULTIPOLYGON":
+		if empty, err := p.empty(); err != nil || empty {
+			return MultiPolygon{}, err
+		}
+		var mp MultiPolygon
+		for {
+			pg, err := p.polygon()
+			if err != nil {
+				return nil, err
+			}
+			mp = append(mp, pg)
+			if !p.next(',') {
+				break
+			}
+		}
+		return mp, p.expect(')')
+	case "GEOMETRYCOLLECTION":
+		if empty, err := p.empty(); err != nil || empty {
+			return GeometryCollection{}, err
+		}
+		var gc GeometryCollection
+		for {
+			g, err := p.geometry()
+			if err != nil {
+				return nil, err
+			}
+			gc = append(gc, g)
+			if !p.next(',') {
+				break
+			}
+		}
+		return gc, p.expect(')')
+	}
+	return nil, p.errorf("unknown type " + tag)
+}
+
+func (p *wktParser) polygon() (Polygon, error) {
+	var pg Polygon
+	if empty, err := p.empty(); err != nil || empty {
+		return pg, err
+	}
+	for i := 0; ; i++ {
+		cds, err := p.coords()
+		if err != nil {
+			return pg, err
+		}
+		if i == 0 {
+			pg.MultiPoint = cds
+		} else {
+			pg.Holes = append(pg.Holes, LineString{MultiPoint: cds})
+		}
+		if !p.next(',') {
+			break
+		}
+	}
+	return pg, p.expect(')')
+}
+
+// coords parses "(x y, x y)" or EMPTY.
+func (p *wktParser) coords() (cds MultiPoint, err error) {
+	if empty, err := p.empty(); err != nil || empty {
+		return nil, err
+	}
+	for {
+		latlong, err := p.coord()
+		if err != nil {
+			return nil, err
+		}
+		cds = append(cds, latlong)
+		if !p.next(',') {
+			break
+		}
+	}
+	return cds, p.expect(')')
+}
+
+func (p *wktParser) number() []byte {
+	p.skipSpace()
+	start := p.i
+	for p.i < len(p.s) && strings.IndexByte("0123456789+-.eE", p.s[p.i]) >= 0 {
+		p.i++
+	}
+	return []byte(p.s[start:p.i])
+}
+
+// coord parses "x y [z] [m]".
+func (p *wktParser) coord() (latlong Point, err error) {
+	var ords [][]byte
+	for len(ords) < 4 {
+		n := p.number()
+		if len(n) == 0 {
+			break
+		}
+		ords = append(ords, n)
+	}
+	if len(ords) < 2 || (p.dims != 0 && len(ords) != p.dims) {
+		return latlong, p.errorf("number of ordinates error")
+	}
+
+	latlong.lng = AngleFromBytes(ords[0])
+	latlong.lat = AngleFromBytes(ords[1])
+	if isErrorDeg(latlong.lng) || isErrorDeg(latlong.lat) {
+		return latlong, p.errorf("coordinate error")
+	}
+	if math.Abs(latlong.lat.Degrees()) > 90 || math.Abs(latlong.lng.Degrees()) > 180 {
+		return latlong, p.errorf("coordinate range error")
+	}
+	if len(ords) > 2 && (p.dims == 0 || p.z) {
+		altitude, err := strconv.ParseFloat(string(ords[2]), 64)
+		if err != nil {
+			return latlong, p.errorf("Z error")
+		}
+		latlong.alt = &altitude
+	}
+	latlong.datum = p.datum
+	return
+}
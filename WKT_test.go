@@ -0,0 +1,123 @@
+package latlong_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	latlong "github.com/toyo/go-latlong"
+)
+
+func TestWKTGeoJSON(t *testing.T) {
+	fixtures := []string{
+		`{"type":"Point","coordinates":[139.7671,35.6812]}`,
+		`{"type":"Point","coordinates":[139.7671,35.6812,3776]}`,
+		geoJSONLineString,
+		geoJSONPolygon,
+	}
+	for _, tt := range geoJSONMultiTests {
+		fixtures = append(fixtures, tt.jsonstring)
+	}
+	for _, jsonstring := range fixtures {
+		var geom latlong.GeoJSONGeometry
+		if err := json.Unmarshal([]byte(jsonstring), &geom); err != nil {
+			t.Errorf("Unmarshal error: %v", err)
+			continue
+		}
+		wkt, err := latlong.MarshalWKT(geom.Geo())
+		if err != nil {
+			t.Errorf("%s: %v", jsonstring, err)
+			continue
+		}
+		g, srid, err := latlong.ParseWKT(string(wkt))
+		if err != nil {
+			t.Errorf("%s: %v", wkt, err)
+			continue
+		}
+		if srid != 0 || !geom.Geo().Equal(g) {
+			t.Errorf("round trip mismatch %s %v", wkt, g)
+		}
+
+		ewkt, err := latlong.MarshalEWKT(geom.Geo(), 4326)
+		if err != nil {
+			t.Errorf("%s: %v", jsonstring, err)
+			continue
+		}
+		// points are tagged by WGS84, so compared in EWKT.
+		g, srid, err = latlong.ParseWKT(string(ewkt))
+		if err != nil || srid != 4326 {
+			t.Errorf("%s: %d %v", ewkt, srid, err)
+			continue
+		}
+		if b, err := latlong.MarshalEWKT(g, srid); err != nil || string(b) != string(ewkt) {
+			t.Errorf("round trip mismatch %s %s", ewkt, b)
+		}
+	}
+}
+
+func TestWKT(t *testing.T) {
+	tests := []struct {
+		in, out string
+	}{
+		{`POINT(139.7671 35.6812)`, `POINT (139.7671 35.6812)`},
+		{`point z (139.7 35.6 -10.5)`, `POINT Z (139.7 35.6 -10.5)`},
+		{`POINTZ (139.7 35.6 10)`, `POINT Z (139.7 35.6 10)`},
+		{`POINT M (139.7 35.6 10)`, `POINT (139.7 35.6)`},
+		{`POINT ZM (139.7 35.6 10 20)`, `POINT Z (139.7 35.6 10)`},
+		{`MULTIPOINT (10 40, 40 30)`, `MULTIPOINT ((10 40), (40 30))`},
+		{`POLYGON ((30 10, 40 40, 20 40, 10 20, 30 10), (20 30, 35 35, 30 20, 20 30))`, `POLYGON ((30 10, 40 40, 20 40, 10 20, 30 10), (20 30, 35 35, 30 20, 20 30))`},
+		{`GEOMETRYCOLLECTION EMPTY`, `GEOMETRYCOLLECTION EMPTY`},
+		{`MULTIPOLYGON EMPTY`, `MULTIPOLYGON EMPTY`},
+	}
+	for _, tt := range tests {
+		g, _, err := latlong.ParseWKT(tt.in)
+		if err != nil {
+			t.Errorf("%s: %v", tt.in, err)
+			continue
+		}
+		if b, err := latlong.MarshalWKT(g); err != nil || string(b) != tt.out {
+			t.Errorf("expected %s, was %s %v", tt.out, b, err)
+		}
+	}
+
+	for _, s := range []string{``, `POINT`, `POINT EMPTY`, `POINT (1)`, `POINT Z (1 2)`, `LINESTRING (1 2, 3 4`, `CURVE (1 2)`, `POINT (1 2) x`, `SRID=x;POINT (1 2)`,
+		`POINT (139 95)`, `POINT (500 35)`, `LINESTRING (0 0, 181 0)`} {
+		if _, _, err := latlong.ParseWKT(s); err == nil {
+			t.Errorf("%s: expected error", s)
+		}
+	}
+}
+
+func TestWKTHoleWithoutZ(t *testing.T) {
+	var pg latlong.Polygon
+	if err := json.Unmarshal([]byte(`[[[30,10,1],[40,40,1],[20,40,1],[30,10,1]],[[25,30],[30,35],[28,25],[25,30]]]`), &pg); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := latlong.MarshalWKT(pg); err != nil || string(b) != `POLYGON ((30 10, 40 40, 20 40, 30 10), (25 30, 30 35, 28 25, 25 30))` {
+		t.Errorf("was %s %v", b, err)
+	}
+	if b, err := latlong.MarshalWKT(latlong.MultiPolygon{pg}); err != nil || string(b) != `MULTIPOLYGON (((30 10, 40 40, 20 40, 30 10), (25 30, 30 35, 28 25, 25 30)))` {
+		t.Errorf("was %s %v", b, err)
+	}
+}
+
+func TestEWKT(t *testing.T) {
+	g, srid, err := latlong.ParseWKT(`SRID=6668;POINT(139.7671 35.6812)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, ok := g.(latlong.Point)
+	if !ok || srid != 6668 || p.Datum() != latlong.DatumJGD2011 {
+		t.Errorf("was %v %d %v", g, srid, p.Datum())
+	}
+	if b, err := latlong.MarshalEWKT(g, srid); err != nil || string(b) != `SRID=6668;POINT (139.7671 35.6812)` {
+		t.Errorf("was %s %v", b, err)
+	}
+
+	rect := latlong.NewRect(35, 135, 1, 2)
+	if s := rect.WKT(); s != `POLYGON ((134.0 34.5, 136.0 34.5, 136.0 35.5, 134.0 35.5, 134.0 34.5))` {
+		t.Errorf("was %s", s)
+	}
+	if s := rect.EWKT(4326); s != `SRID=4326;POLYGON ((134.0 34.5, 136.0 34.5, 136.0 35.5, 134.0 35.5, 134.0 34.5))` {
+		t.Errorf("was %s", s)
+	}
+}